@@ -1,14 +1,19 @@
 package heap
 
 import (
+	"bytes"
 	"container/heap"
 	"encoding/binary"
 	"encoding/hex"
+	"flag"
+	"fmt"
 	"math/rand"
 	"sort"
 	"testing"
 )
 
+var benchLarge = flag.Bool("heap.large", false, "run benchmarks with 1e8 elements")
+
 func BenchmarkHeapAndSort(b *testing.B) {
 	const n = 100_000
 	values := make([]string, n)
@@ -83,3 +88,146 @@ func (h *strMaxHeap) Pop() interface{} {
 	*h = old[0 : n-1]
 	return x
 }
+
+func BenchmarkBytesAndStr(b *testing.B) {
+	const n = 100_000
+	keys := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		var k [16]byte
+		binary.BigEndian.PutUint64(k[:8], rand.Uint64())
+		binary.BigEndian.PutUint64(k[8:], uint64(i))
+		keys[i] = k[:]
+	}
+
+	b.Run("minbytes", func(b *testing.B) {
+		b.ReportAllocs()
+		h := MinBytes(make([][]byte, 0, n))
+		for i := 0; i < b.N; i++ {
+			for _, k := range keys {
+				h.Push(k)
+			}
+			for len(h) > 0 {
+				_ = h.Pop()
+			}
+		}
+	})
+	b.Run("bytesfunc", func(b *testing.B) {
+		b.ReportAllocs()
+		h := BytesFunc{Values: make([][]byte, 0, n), Cmp: bytes.Compare}
+		for i := 0; i < b.N; i++ {
+			for _, k := range keys {
+				h.Push(k)
+			}
+			for len(h.Values) > 0 {
+				_ = h.Pop()
+			}
+		}
+	})
+	b.Run("minstr", func(b *testing.B) {
+		b.ReportAllocs()
+		h := MinStr(make([]string, 0, n))
+		for i := 0; i < b.N; i++ {
+			for _, k := range keys {
+				h.Push(string(k))
+			}
+			for len(h) > 0 {
+				_ = []byte(h.Pop())
+			}
+		}
+	})
+}
+
+// largeSizes returns the heap sizes for the benchmarks of large heaps,
+// which measure a Pop followed by a Push of a random value.
+// 1e8 elements need about 800 MB and are used only with -heap.large.
+func largeSizes() []int {
+	sizes := []int{1e6, 1e7}
+	if *benchLarge {
+		sizes = append(sizes, 1e8)
+	}
+	return sizes
+}
+
+func BenchmarkLargeUint64(b *testing.B) {
+	for _, n := range largeSizes() {
+		values := make([]uint64, n)
+		rnd := rand.New(rand.NewSource(2))
+		for i := range values {
+			values[i] = rnd.Uint64()
+		}
+		b.Run(fmt.Sprintf("MinUint64/%.0e", float64(n)), func(b *testing.B) {
+			h := MinUint64(append([]uint64(nil), values...))
+			h.Init()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				h.Pop()
+				h.Push(rnd.Uint64())
+			}
+		})
+		b.Run(fmt.Sprintf("BlockedMinUint64/%.0e", float64(n)), func(b *testing.B) {
+			h := BlockedMinUint64(append([]uint64(nil), values...))
+			h.Init()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				h.Pop()
+				h.Push(rnd.Uint64())
+			}
+		})
+	}
+}
+
+func BenchmarkLargeInt64(b *testing.B) {
+	for _, n := range largeSizes() {
+		values := make([]int64, n)
+		rnd := rand.New(rand.NewSource(2))
+		for i := range values {
+			values[i] = rnd.Int63()
+		}
+		b.Run(fmt.Sprintf("MinInt64/%.0e", float64(n)), func(b *testing.B) {
+			h := MinInt64(append([]int64(nil), values...))
+			h.Init()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				h.Pop()
+				h.Push(rnd.Int63())
+			}
+		})
+		b.Run(fmt.Sprintf("BlockedMinInt64/%.0e", float64(n)), func(b *testing.B) {
+			h := BlockedMinInt64(append([]int64(nil), values...))
+			h.Init()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				h.Pop()
+				h.Push(rnd.Int63())
+			}
+		})
+	}
+}
+
+func BenchmarkSelect(b *testing.B) {
+	const n = 100_000
+	values := make([]int64, n)
+	rnd := rand.New(rand.NewSource(3))
+	for i := range values {
+		values[i] = rnd.Int63()
+	}
+	for _, k := range []int{10, 1000, n / 16, n / 4} {
+		b.Run(fmt.Sprintf("KthSmallestInt64/k=%d", k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				KthSmallestInt64(values, k)
+			}
+		})
+		b.Run(fmt.Sprintf("NSmallest/k=%d", k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NSmallest(values, k)
+			}
+		})
+		b.Run(fmt.Sprintf("sort/k=%d", k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s := append([]int64(nil), values...)
+				sort.Slice(s, func(i, j int) bool { return s[i] < s[j] })
+				_ = s[:k]
+			}
+		})
+	}
+}
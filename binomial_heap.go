@@ -0,0 +1,212 @@
+package heap
+
+import "cmp"
+
+// BinomialHeap is a binomial heap for getting the minimum key.
+// Two binomial heaps can be melded in O(log n) time.
+// Elements are referenced by node handles like FibHeap.
+//
+// The zero value for BinomialHeap is an empty heap ready to use.
+type BinomialHeap[K cmp.Ordered, V any] struct {
+	head *binomialTree[K, V] // roots in increasing order of degree
+	n    int
+}
+
+// BinomialNode is a handle of an element in BinomialHeap.
+type BinomialNode[K cmp.Ordered, V any] struct {
+	key   K
+	Value V
+	tree  *binomialTree[K, V]
+}
+
+// Key returns the key of the node.
+func (x *BinomialNode[K, V]) Key() K { return x.key }
+
+// binomialTree is a node of a binomial tree. Elements are swapped between
+// tree nodes when they move up, so that handles stay valid.
+type binomialTree[K cmp.Ordered, V any] struct {
+	elem    *BinomialNode[K, V]
+	parent  *binomialTree[K, V]
+	child   *binomialTree[K, V] // child with the largest degree
+	sibling *binomialTree[K, V]
+	degree  int
+}
+
+// Len returns the number of elements in the heap.
+func (h *BinomialHeap[K, V]) Len() int { return h.n }
+
+// Insert inserts an element with the key and the value, and returns
+// the handle of the element.
+// The complexity is O(log n) where n = h.Len().
+func (h *BinomialHeap[K, V]) Insert(key K, value V) *BinomialNode[K, V] {
+	x := &BinomialNode[K, V]{key: key, Value: value}
+	x.tree = &binomialTree[K, V]{elem: x}
+	h.head = unionBinomial(h.head, x.tree)
+	h.n++
+	return x
+}
+
+// PeekMin returns the handle of the element with the minimum key,
+// or nil if the heap is empty.
+// The complexity is O(log n) where n = h.Len().
+func (h *BinomialHeap[K, V]) PeekMin() *BinomialNode[K, V] {
+	if h.head == nil {
+		return nil
+	}
+	return h.minRoot(nil).elem
+}
+
+// ExtractMin removes the element with the minimum key from the heap and
+// returns its handle, or nil if the heap is empty.
+// The complexity is O(log n) where n = h.Len().
+func (h *BinomialHeap[K, V]) ExtractMin() *BinomialNode[K, V] {
+	if h.head == nil {
+		return nil
+	}
+	var prev *binomialTree[K, V]
+	x := h.minRoot(&prev)
+	h.removeRoot(x, prev)
+	return x.elem
+}
+
+// Meld moves all the elements of other to h. other becomes empty.
+// The complexity is O(log n) where n = h.Len() + other.Len().
+func (h *BinomialHeap[K, V]) Meld(other *BinomialHeap[K, V]) {
+	if other == h {
+		return
+	}
+	h.head = unionBinomial(h.head, other.head)
+	h.n += other.n
+	other.head, other.n = nil, 0
+}
+
+// DecreaseKey changes the key of the element x, which must be in the heap,
+// to the key. DecreaseKey panics if the key is greater than the current key.
+// The complexity is O(log n) where n = h.Len().
+func (h *BinomialHeap[K, V]) DecreaseKey(x *BinomialNode[K, V], key K) {
+	if key > x.key {
+		panic("heap: DecreaseKey with a greater key")
+	}
+	x.key = key
+	h.up(x.tree, false)
+}
+
+// Delete removes the element x, which must be in the heap, from the heap.
+// The complexity is O(log n) where n = h.Len().
+func (h *BinomialHeap[K, V]) Delete(x *BinomialNode[K, V]) {
+	// Move x to the root as if its key were decreased to
+	// negative infinity, and then remove the root.
+	t := h.up(x.tree, true)
+	var prev *binomialTree[K, V]
+	for r := h.head; r != t; r = r.sibling {
+		prev = r
+	}
+	h.removeRoot(t, prev)
+}
+
+// up moves the element of the tree node t up while its key is less than
+// the key of the parent, or up to the root if force is true.
+// It returns the tree node which holds the element finally.
+func (h *BinomialHeap[K, V]) up(t *binomialTree[K, V], force bool) *binomialTree[K, V] {
+	for p := t.parent; p != nil && (force || t.elem.key < p.elem.key); t, p = p, p.parent {
+		t.elem, p.elem = p.elem, t.elem
+		t.elem.tree, p.elem.tree = t, p
+	}
+	return t
+}
+
+// minRoot returns the root with the minimum key. If prev is not nil,
+// the root before it is stored to *prev.
+func (h *BinomialHeap[K, V]) minRoot(prev **binomialTree[K, V]) *binomialTree[K, V] {
+	min := h.head
+	var minPrev, p *binomialTree[K, V]
+	for r := h.head; r != nil; p, r = r, r.sibling {
+		if r.elem.key < min.elem.key {
+			min, minPrev = r, p
+		}
+	}
+	if prev != nil {
+		*prev = minPrev
+	}
+	return min
+}
+
+// removeRoot removes the root x, which follows prev in the root list,
+// and melds its children back to the heap.
+func (h *BinomialHeap[K, V]) removeRoot(x, prev *binomialTree[K, V]) {
+	if prev == nil {
+		h.head = x.sibling
+	} else {
+		prev.sibling = x.sibling
+	}
+
+	// The children are in decreasing order of degree, so reverse them.
+	var children *binomialTree[K, V]
+	for c := x.child; c != nil; {
+		next := c.sibling
+		c.parent = nil
+		c.sibling = children
+		children = c
+		c = next
+	}
+	h.head = unionBinomial(h.head, children)
+	h.n--
+	x.elem.tree = nil
+}
+
+// unionBinomial unions two root lists in increasing order of degree
+// and returns the root list where all roots have distinct degrees.
+func unionBinomial[K cmp.Ordered, V any](a, b *binomialTree[K, V]) *binomialTree[K, V] {
+	head := mergeRootLists(a, b)
+	if head == nil {
+		return nil
+	}
+	var prev *binomialTree[K, V]
+	x, next := head, head.sibling
+	for next != nil {
+		if x.degree != next.degree || (next.sibling != nil && next.sibling.degree == x.degree) {
+			prev, x = x, next
+		} else if x.elem.key <= next.elem.key {
+			x.sibling = next.sibling
+			linkBinomial(next, x)
+		} else {
+			if prev == nil {
+				head = next
+			} else {
+				prev.sibling = next
+			}
+			linkBinomial(x, next)
+			x = next
+		}
+		next = x.sibling
+	}
+	return head
+}
+
+// mergeRootLists merges two root lists in increasing order of degree.
+func mergeRootLists[K cmp.Ordered, V any](a, b *binomialTree[K, V]) *binomialTree[K, V] {
+	var head binomialTree[K, V]
+	tail := &head
+	for a != nil && b != nil {
+		if a.degree <= b.degree {
+			tail.sibling, a = a, a.sibling
+		} else {
+			tail.sibling, b = b, b.sibling
+		}
+		tail = tail.sibling
+	}
+	if a != nil {
+		tail.sibling = a
+	} else {
+		tail.sibling = b
+	}
+	return head.sibling
+}
+
+// linkBinomial makes the root y a child of the root x of the same degree.
+func linkBinomial[K cmp.Ordered, V any](y, x *binomialTree[K, V]) {
+	y.parent = x
+	y.sibling = x.child
+	x.child = y
+	x.degree++
+}
@@ -0,0 +1,164 @@
+package heap
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func (h *BinomialHeap[K, V]) verify(t *testing.T) {
+	t.Helper()
+	var walk func(x *binomialTree[K, V]) int
+	walk = func(x *binomialTree[K, V]) int {
+		if x.elem.tree != x {
+			t.Errorf("element %v has wrong tree node", x.elem.key)
+		}
+		n := 1
+		d := x.degree
+		for c := x.child; c != nil; c = c.sibling {
+			d--
+			if c.degree != d {
+				t.Errorf("child of %v has degree %d; want %d", x.elem.key, c.degree, d)
+			}
+			if c.parent != x {
+				t.Errorf("child %v has wrong parent", c.elem.key)
+			}
+			if c.elem.key < x.elem.key {
+				t.Errorf("heap invariant invalidated %v > %v", x.elem.key, c.elem.key)
+			}
+			n += walk(c)
+		}
+		if d != 0 {
+			t.Errorf("node %v has degree %d but %d children", x.elem.key, x.degree, x.degree-d)
+		}
+		return n
+	}
+	n := 0
+	for r := h.head; r != nil; r = r.sibling {
+		if r.parent != nil {
+			t.Errorf("root %v has a parent", r.elem.key)
+		}
+		if r.sibling != nil && r.sibling.degree <= r.degree {
+			t.Errorf("roots are not in increasing order of degree")
+		}
+		n += walk(r)
+	}
+	if n != h.n {
+		t.Errorf("heap has %d nodes; want %d", n, h.n)
+	}
+}
+
+func TestBinomialHeap(t *testing.T) {
+	h := new(BinomialHeap[uint64, int])
+	h.verify(t)
+	if h.PeekMin() != nil || h.ExtractMin() != nil {
+		t.Fatal("empty heap has an element")
+	}
+
+	for i := 20; i > 10; i-- {
+		h.Insert(uint64(i), i)
+	}
+	h.verify(t)
+
+	for i := 10; i > 0; i-- {
+		h.Insert(uint64(i), i)
+		h.verify(t)
+	}
+
+	for i := 1; h.Len() > 0; i++ {
+		if x := h.PeekMin(); x.Key() != uint64(i) {
+			t.Errorf("%d.th min got %d; want %d", i, x.Key(), uint64(i))
+		}
+		x := h.ExtractMin()
+		if i < 20 {
+			h.Insert(uint64(20+i), 20+i)
+		}
+		h.verify(t)
+		if x.Key() != uint64(i) || x.Value != i {
+			t.Errorf("%d.th extract got %d, %d; want %d, %d", i, x.Key(), x.Value, uint64(i), i)
+		}
+	}
+}
+
+// TestBinomialHeapQuick checks that BinomialHeap and MinUint64 produce the same
+// outputs for random operation sequences.
+func TestBinomialHeapQuick(t *testing.T) {
+	f := func(seed int64) bool {
+		rnd := rand.New(rand.NewSource(seed))
+		h := new(BinomialHeap[uint64, struct{}])
+		var nodes []*BinomialNode[uint64, struct{}]
+		oracle := new(MinUint64)
+
+		oracleIndex := func(key uint64) int {
+			for i, v := range *oracle {
+				if v == key {
+					return i
+				}
+			}
+			panic("key not found in oracle")
+		}
+		removeNode := func(x *BinomialNode[uint64, struct{}]) {
+			for i, y := range nodes {
+				if y == x {
+					nodes[i] = nodes[len(nodes)-1]
+					nodes = nodes[:len(nodes)-1]
+					return
+				}
+			}
+		}
+
+		for step := 0; step < 500; step++ {
+			switch op := rnd.Intn(10); {
+			case op < 4 || len(nodes) == 0:
+				key := uint64(rnd.Intn(1000)) + 1000
+				nodes = append(nodes, h.Insert(key, struct{}{}))
+				oracle.Push(key)
+			case op < 6:
+				x := h.ExtractMin()
+				if x.Key() != oracle.Pop() {
+					return false
+				}
+				removeNode(x)
+			case op < 8:
+				x := nodes[rnd.Intn(len(nodes))]
+				i := oracleIndex(x.Key())
+				key := x.Key() - uint64(rnd.Intn(10))
+				h.DecreaseKey(x, key)
+				(*oracle)[i] = key
+				oracle.Fix(i)
+			case op < 9:
+				x := nodes[rnd.Intn(len(nodes))]
+				h.Delete(x)
+				oracle.Remove(oracleIndex(x.Key()))
+				removeNode(x)
+			default:
+				other := new(BinomialHeap[uint64, struct{}])
+				for i := rnd.Intn(20); i > 0; i-- {
+					key := uint64(rnd.Intn(1000)) + 1000
+					nodes = append(nodes, other.Insert(key, struct{}{}))
+					oracle.Push(key)
+				}
+				h.Meld(other)
+				if other.Len() != 0 || other.PeekMin() != nil {
+					return false
+				}
+			}
+			if h.Len() != len(*oracle) {
+				return false
+			}
+			if h.Len() > 0 && h.PeekMin().Key() != (*oracle)[0] {
+				return false
+			}
+		}
+		h.verify(t)
+		for h.Len() > 0 {
+			if h.ExtractMin().Key() != oracle.Pop() {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
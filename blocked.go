@@ -0,0 +1,5 @@
+package heap
+
+// blockSize is the number of children of a node in the blocked heaps,
+// which is the number of 8-byte elements in a 64-byte cache line.
+const blockSize = 8
@@ -0,0 +1,231 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package heap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// verify checks the heap invariants between the elements at index i or later
+// and their parents.
+func (h *BlockedMinInt64) verify(t *testing.T, i int) {
+	t.Helper()
+	n := h.length()
+	for j := max(i, 1); j < n; j++ {
+		p := j / blockSize
+		if h.less(j, p) {
+			t.Errorf("heap invariant invalidated [%d] = %d > [%d] = %d", p, (*h)[p], j, (*h)[j])
+			return
+		}
+	}
+}
+
+func TestBlockedMinInt64Init0(t *testing.T) {
+	h := new(BlockedMinInt64)
+	for i := 20; i > 0; i-- {
+		h.Push(0) // all elements are the same
+	}
+	h.Init()
+	h.verify(t, 0)
+
+	for i := 1; h.length() > 0; i++ {
+		x := h.Pop()
+		h.verify(t, 0)
+		if x != 0 {
+			t.Errorf("%d.th pop got %d; want %d", i, x, 0)
+		}
+	}
+}
+
+func TestBlockedMinInt64Init1(t *testing.T) {
+	h := new(BlockedMinInt64)
+	for i := 20; i > 0; i-- {
+		h.Push(int64(i)) // all elements are different
+	}
+	h.Init()
+	h.verify(t, 0)
+
+	for i := 1; h.length() > 0; i++ {
+		x := h.Pop()
+		h.verify(t, 0)
+		if x != int64(i) {
+			t.Errorf("%d.th pop got %d; want %d", i, x, int64(i))
+		}
+	}
+}
+
+func TestBlockedMinInt64(t *testing.T) {
+	h := new(BlockedMinInt64)
+	h.verify(t, 0)
+
+	for i := 20; i > 10; i-- {
+		h.push(int64(i))
+	}
+	h.Init()
+	h.verify(t, 0)
+
+	for i := 10; i > 0; i-- {
+		h.Push(int64(i))
+		h.verify(t, 0)
+	}
+
+	for i := 1; h.length() > 0; i++ {
+		x := h.Pop()
+		if i < 20 {
+			h.Push(int64(20 + i))
+		}
+		h.verify(t, 0)
+		if x != int64(i) {
+			t.Errorf("%d.th pop got %d; want %d", i, x, int64(i))
+		}
+	}
+}
+
+func TestBlockedMinInt64Remove0(t *testing.T) {
+	h := new(BlockedMinInt64)
+	for i := 0; i < 10; i++ {
+		h.push(int64(i))
+	}
+	h.verify(t, 0)
+
+	for h.length() > 0 {
+		i := h.length() - 1
+		x := h.Remove(i)
+		if x != int64(i) {
+			t.Errorf("Remove(%d) got %d; want %d", i, x, int64(i))
+		}
+		h.verify(t, 0)
+	}
+}
+
+func TestBlockedMinInt64Remove1(t *testing.T) {
+	h := new(BlockedMinInt64)
+	for i := 0; i < 10; i++ {
+		h.push(int64(i))
+	}
+	h.verify(t, 0)
+
+	for i := 0; h.length() > 0; i++ {
+		x := h.Remove(0)
+		if x != int64(i) {
+			t.Errorf("Remove(0) got %d; want %d", x, int64(i))
+		}
+		h.verify(t, 0)
+	}
+}
+
+func TestBlockedMinInt64Remove2(t *testing.T) {
+	N := 10
+
+	h := new(BlockedMinInt64)
+	for i := 0; i < N; i++ {
+		h.push(int64(i))
+	}
+	h.verify(t, 0)
+
+	m := make(map[int64]bool)
+	for h.length() > 0 {
+		m[h.Remove((h.length()-1)/2)] = true
+		h.verify(t, 0)
+	}
+
+	if len(m) != N {
+		t.Errorf("len(m) = %d; want %d", len(m), N)
+	}
+	for i := 0; i < len(m); i++ {
+		k := int64(i)
+		if !m[k] {
+			t.Errorf("m[%d] doesn't exist", k)
+		}
+	}
+}
+
+func BenchmarkBlockedMinInt64Dup(b *testing.B) {
+	const n = 10000
+	h := make(BlockedMinInt64, 0, n)
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < n; j++ {
+			h.Push(0) // all elements are the same
+		}
+		for h.length() > 0 {
+			h.Pop()
+		}
+	}
+}
+
+func TestBlockedMinInt64Fix(t *testing.T) {
+	h := new(BlockedMinInt64)
+	h.verify(t, 0)
+
+	for i := 200; i > 0; i -= 10 {
+		h.Push(int64(i))
+	}
+	h.verify(t, 0)
+
+	if (*h)[0] != 10 {
+		t.Fatalf("Expected head to be 10, was %d", (*h)[0])
+	}
+	(*h)[0] = 210
+	h.Fix(0)
+	h.verify(t, 0)
+
+	for i := 100; i > 0; i-- {
+		elem := rand.Intn(h.length())
+		if i&1 == 0 {
+			(*h)[elem] *= 2
+		} else {
+			(*h)[elem] /= 2
+		}
+		h.Fix(elem)
+		h.verify(t, 0)
+	}
+}
+
+func TestBlockedMinInt64Random(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	h := new(BlockedMinInt64)
+	oracle := new(MinInt64)
+	for step := 0; step < 5000; step++ {
+		switch op := rnd.Intn(8); {
+		case op < 4 || h.length() == 0:
+			x := int64(rnd.Intn(1000) - 500)
+			h.Push(x)
+			oracle.Push(x)
+		case op < 6:
+			if x, want := h.Pop(), oracle.Pop(); x != want {
+				t.Fatalf("step %d: Pop got %d; want %d", step, x, want)
+			}
+		case op < 7:
+			x := h.Remove(rnd.Intn(h.length()))
+			i := slices.Index(*oracle, x)
+			if i < 0 {
+				t.Fatalf("step %d: Remove got %d, which was not pushed", step, x)
+			}
+			oracle.Remove(i)
+		default:
+			i := rnd.Intn(h.length())
+			old, x := (*h)[i], int64(rnd.Intn(1000)-500)
+			(*h)[i] = x
+			h.Fix(i)
+			j := slices.Index(*oracle, old)
+			if j < 0 {
+				t.Fatalf("step %d: element %d at %d was not pushed", step, old, i)
+			}
+			(*oracle)[j] = x
+			oracle.Fix(j)
+		}
+		h.verify(t, 0)
+		if h.length() != oracle.length() {
+			t.Fatalf("step %d: length = %d; want %d", step, h.length(), oracle.length())
+		}
+	}
+	for h.length() > 0 {
+		if x, want := h.Pop(), oracle.Pop(); x != want {
+			t.Fatalf("Pop got %d; want %d", x, want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package heap
+
+// BlockedMinUint64 is a heap for getting the minimum uint64 value with
+// a cache-friendly memory layout for large heaps.
+//
+// It is an 8-ary heap where the children of the element at index i are at
+// index 8*i to 8*i+7, except that the children of the root are at index 1
+// to 7. Each group of siblings is 8 consecutive elements starting at
+// a multiple of 8, which spans at most two 64-byte cache lines, or one
+// when the backing array is 64-byte aligned, so down touches at most two
+// cache lines per level and the depth is a third of a binary heap.
+// Whether it is faster than MinUint64 depends on the hardware and
+// the workload, so compare them with BenchmarkLargeUint64 before use.
+// The public API is the same as MinUint64.
+type BlockedMinUint64 []uint64
+
+// Init establishes the heap invariants required by the other routines in this package.
+// Init is idempotent with respect to the heap invariants
+// and may be called whenever the heap invariants may have been invalidated.
+// The complexity is O(n) where n = len(*h).
+func (h *BlockedMinUint64) Init() {
+	// heapify
+	n := h.length()
+	for i := (n - 1) / blockSize; i >= 0; i-- {
+		h.down(i, n)
+	}
+}
+
+// Push pushes the element x onto the heap.
+// The complexity is O(log n) where n = len(*h).
+func (h *BlockedMinUint64) Push(x uint64) {
+	h.push(x)
+	h.up(h.length() - 1)
+}
+
+// Pop removes and returns the minimum element from the heap.
+// The complexity is O(log n) where n = len(*h).
+// Pop is equivalent to Remove(h, 0).
+func (h *BlockedMinUint64) Pop() uint64 {
+	n := h.length() - 1
+	h.swap(0, n)
+	h.down(0, n)
+	return h.pop()
+}
+
+// Remove removes and returns the element at index i from the heap.
+// The complexity is O(log n) where n = len(*h).
+func (h *BlockedMinUint64) Remove(i int) uint64 {
+	n := h.length() - 1
+	if n != i {
+		h.swap(i, n)
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+	return h.pop()
+}
+
+// Fix re-establishes the heap ordering after the element at index i has changed its value.
+// Changing the value of the element at index i and then calling Fix is equivalent to,
+// but less expensive than, calling Remove(h, i) followed by a Push of the new value.
+// The complexity is O(log n) where n = len(*h).
+func (h *BlockedMinUint64) Fix(i int) {
+	if !h.down(i, h.length()) {
+		h.up(i)
+	}
+}
+
+func (h *BlockedMinUint64) up(j int) {
+	for j > 0 {
+		i := j / blockSize // parent
+		if !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		j = i
+	}
+}
+
+func (h *BlockedMinUint64) down(i0, n int) bool {
+	a := *h
+	i := i0
+	for {
+		j1 := blockSize * i // first child
+		if j1 == 0 {
+			j1 = 1 // the root is not a child of itself
+		}
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		end := blockSize*i + blockSize // end of children
+		if end > n || end < 0 {
+			end = n
+		}
+		j, m := j1, a[j1] // least child
+		for j2, v := range a[j1+1 : end] {
+			if v < m {
+				j, m = j1+1+j2, v
+			}
+		}
+		if !(m < a[i]) {
+			break
+		}
+		a[i], a[j] = m, a[i]
+		i = j
+	}
+	return i > i0
+}
+
+func (h BlockedMinUint64) length() int        { return len(h) }
+func (h BlockedMinUint64) less(i, j int) bool { return h[i] < h[j] }
+func (h BlockedMinUint64) swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *BlockedMinUint64) push(x uint64) {
+	*h = append(*h, x)
+}
+
+func (h *BlockedMinUint64) pop() (x uint64) {
+	*h, x = (*h)[:h.length()-1], (*h)[h.length()-1]
+	return
+}
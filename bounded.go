@@ -0,0 +1,34 @@
+package heap
+
+import "strconv"
+
+// OverflowPolicy is a policy of a bounded heap for pushing an element
+// when the heap already has the maximum number of elements.
+type OverflowPolicy int
+
+const (
+	// OverflowReject drops the pushed element.
+	OverflowReject OverflowPolicy = iota
+
+	// OverflowEvictWorst drops the worst element among the elements of the heap
+	// and the pushed one, that is the maximum for minimum heaps and the minimum
+	// for maximum heaps. The heap keeps the best elements pushed so far.
+	OverflowEvictWorst
+
+	// OverflowEvictBest drops the best element among the elements of the heap
+	// and the pushed one, that is the element which would be popped next.
+	OverflowEvictBest
+)
+
+// String returns the name of p.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowReject:
+		return "OverflowReject"
+	case OverflowEvictWorst:
+		return "OverflowEvictWorst"
+	case OverflowEvictBest:
+		return "OverflowEvictBest"
+	}
+	return "OverflowPolicy(" + strconv.Itoa(int(p)) + ")"
+}
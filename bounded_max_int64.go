@@ -0,0 +1,55 @@
+package heap
+
+// BoundedMaxInt64 is a heap for getting the maximum int64 value which holds
+// at most a fixed number of elements. When an element is pushed to a full
+// heap, an element is dropped according to the overflow policy.
+//
+// The elements are kept in an interval heap, so that both the minimum and
+// the maximum elements can be dropped in O(log n) time.
+type BoundedMaxInt64 struct {
+	h      IntervalInt64
+	maxLen int
+	policy OverflowPolicy
+}
+
+// NewBoundedMaxInt64 returns an empty heap which holds at most maxLen elements
+// and drops elements according to policy when it is full.
+// NewBoundedMaxInt64 panics if maxLen is negative.
+func NewBoundedMaxInt64(maxLen int, policy OverflowPolicy) *BoundedMaxInt64 {
+	if maxLen < 0 {
+		panic("heap: negative maximum length")
+	}
+	return &BoundedMaxInt64{maxLen: maxLen, policy: policy}
+}
+
+// Len returns the number of elements in the heap.
+func (h *BoundedMaxInt64) Len() int { return len(h.h) }
+
+// MaxLen returns the maximum number of elements in the heap.
+func (h *BoundedMaxInt64) MaxLen() int { return h.maxLen }
+
+// Peek returns the maximum element of the heap without removing it.
+// The complexity is O(1).
+func (h *BoundedMaxInt64) Peek() int64 { return h.h.PeekMax() }
+
+// Push pushes the element x onto the heap. If the heap is full, it drops
+// an element according to the overflow policy and returns the dropped
+// element, which may be x itself, with overflow set to true.
+// The complexity is O(log n) where n = h.Len().
+func (h *BoundedMaxInt64) Push(x int64) (dropped int64, overflow bool) {
+	if len(h.h) < h.maxLen {
+		h.h.Push(x)
+		return 0, false
+	}
+	switch h.policy {
+	case OverflowEvictWorst:
+		return h.h.PushPopMin(x), true
+	case OverflowEvictBest:
+		return h.h.PushPopMax(x), true
+	}
+	return x, true
+}
+
+// Pop removes and returns the maximum element from the heap.
+// The complexity is O(log n) where n = h.Len().
+func (h *BoundedMaxInt64) Pop() int64 { return h.h.PopMax() }
@@ -0,0 +1,69 @@
+package heap
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestBoundedMinInt64(t *testing.T) {
+	h := NewBoundedMinInt64(3, OverflowEvictWorst)
+	for _, x := range []int64{5, 1, 4, 2, 3} {
+		h.Push(x)
+	}
+	if h.Len() != 3 || h.MaxLen() != 3 {
+		t.Fatalf("Len(), MaxLen() = %d, %d; want 3, 3", h.Len(), h.MaxLen())
+	}
+	for _, want := range []int64{1, 2, 3} {
+		if x := h.Pop(); x != want {
+			t.Errorf("Pop() = %d; want %d", x, want)
+		}
+	}
+}
+
+func TestBoundedMinInt64Policies(t *testing.T) {
+	for _, policy := range []OverflowPolicy{OverflowReject, OverflowEvictWorst, OverflowEvictBest} {
+		for _, maxLen := range []int{0, 1, 2, 10} {
+			h := NewBoundedMinInt64(maxLen, policy)
+			var oracle []int64 // sorted from the best to the worst
+			for i := 0; i < 500; i++ {
+				if rand.Intn(3) == 0 && len(oracle) > 0 {
+					if x := h.Pop(); x != oracle[0] {
+						t.Fatalf("%v, %d: Pop() = %d; want %d", policy, maxLen, x, oracle[0])
+					}
+					oracle = oracle[1:]
+					continue
+				}
+
+				x := rand.Int63n(20) - 10
+				j, _ := slices.BinarySearchFunc(oracle, x, cmp.Compare[int64])
+				oracle = slices.Insert(oracle, j, x)
+				var want int64
+				wantOverflow := len(oracle) > maxLen
+				if wantOverflow {
+					switch policy {
+					case OverflowReject:
+						want = x
+						oracle = slices.Delete(oracle, j, j+1)
+					case OverflowEvictWorst:
+						want = oracle[len(oracle)-1]
+						oracle = oracle[:len(oracle)-1]
+					case OverflowEvictBest:
+						want = oracle[0]
+						oracle = oracle[1:]
+					}
+				}
+				if got, overflow := h.Push(x); got != want || overflow != wantOverflow {
+					t.Fatalf("%v, %d: Push(%d) = %d, %v; want %d, %v", policy, maxLen, x, got, overflow, want, wantOverflow)
+				}
+				if h.Len() != len(oracle) {
+					t.Fatalf("%v, %d: Len() = %d; want %d", policy, maxLen, h.Len(), len(oracle))
+				}
+				if len(oracle) > 0 && h.Peek() != oracle[0] {
+					t.Fatalf("%v, %d: Peek() = %d; want %d", policy, maxLen, h.Peek(), oracle[0])
+				}
+			}
+		}
+	}
+}
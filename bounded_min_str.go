@@ -0,0 +1,55 @@
+package heap
+
+// BoundedMinStr is a heap for getting the minimum string value which holds
+// at most a fixed number of elements. When an element is pushed to a full
+// heap, an element is dropped according to the overflow policy.
+//
+// The elements are kept in an interval heap, so that both the minimum and
+// the maximum elements can be dropped in O(log n) time.
+type BoundedMinStr struct {
+	h      IntervalStr
+	maxLen int
+	policy OverflowPolicy
+}
+
+// NewBoundedMinStr returns an empty heap which holds at most maxLen elements
+// and drops elements according to policy when it is full.
+// NewBoundedMinStr panics if maxLen is negative.
+func NewBoundedMinStr(maxLen int, policy OverflowPolicy) *BoundedMinStr {
+	if maxLen < 0 {
+		panic("heap: negative maximum length")
+	}
+	return &BoundedMinStr{maxLen: maxLen, policy: policy}
+}
+
+// Len returns the number of elements in the heap.
+func (h *BoundedMinStr) Len() int { return len(h.h) }
+
+// MaxLen returns the maximum number of elements in the heap.
+func (h *BoundedMinStr) MaxLen() int { return h.maxLen }
+
+// Peek returns the minimum element of the heap without removing it.
+// The complexity is O(1).
+func (h *BoundedMinStr) Peek() string { return h.h.PeekMin() }
+
+// Push pushes the element x onto the heap. If the heap is full, it drops
+// an element according to the overflow policy and returns the dropped
+// element, which may be x itself, with overflow set to true.
+// The complexity is O(log n) where n = h.Len().
+func (h *BoundedMinStr) Push(x string) (dropped string, overflow bool) {
+	if len(h.h) < h.maxLen {
+		h.h.Push(x)
+		return "", false
+	}
+	switch h.policy {
+	case OverflowEvictWorst:
+		return h.h.PushPopMax(x), true
+	case OverflowEvictBest:
+		return h.h.PushPopMin(x), true
+	}
+	return x, true
+}
+
+// Pop removes and returns the minimum element from the heap.
+// The complexity is O(log n) where n = h.Len().
+func (h *BoundedMinStr) Pop() string { return h.h.PopMin() }
@@ -0,0 +1,69 @@
+package heap
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBoundedMinStr(t *testing.T) {
+	h := NewBoundedMinStr(3, OverflowEvictWorst)
+	for _, x := range []string{"e", "a", "d", "b", "c"} {
+		h.Push(x)
+	}
+	if h.Len() != 3 || h.MaxLen() != 3 {
+		t.Fatalf("Len(), MaxLen() = %d, %d; want 3, 3", h.Len(), h.MaxLen())
+	}
+	for _, want := range []string{"a", "b", "c"} {
+		if x := h.Pop(); x != want {
+			t.Errorf("Pop() = %q; want %q", x, want)
+		}
+	}
+}
+
+func TestBoundedMinStrPolicies(t *testing.T) {
+	for _, policy := range []OverflowPolicy{OverflowReject, OverflowEvictWorst, OverflowEvictBest} {
+		for _, maxLen := range []int{0, 1, 2, 10} {
+			h := NewBoundedMinStr(maxLen, policy)
+			var oracle []string // sorted from the best to the worst
+			for i := 0; i < 500; i++ {
+				if rand.Intn(3) == 0 && len(oracle) > 0 {
+					if x := h.Pop(); x != oracle[0] {
+						t.Fatalf("%v, %d: Pop() = %q; want %q", policy, maxLen, x, oracle[0])
+					}
+					oracle = oracle[1:]
+					continue
+				}
+
+				x := toHex(uint64(rand.Intn(20)))
+				j, _ := slices.BinarySearchFunc(oracle, x, strings.Compare)
+				oracle = slices.Insert(oracle, j, x)
+				var want string
+				wantOverflow := len(oracle) > maxLen
+				if wantOverflow {
+					switch policy {
+					case OverflowReject:
+						want = x
+						oracle = slices.Delete(oracle, j, j+1)
+					case OverflowEvictWorst:
+						want = oracle[len(oracle)-1]
+						oracle = oracle[:len(oracle)-1]
+					case OverflowEvictBest:
+						want = oracle[0]
+						oracle = oracle[1:]
+					}
+				}
+				if got, overflow := h.Push(x); got != want || overflow != wantOverflow {
+					t.Fatalf("%v, %d: Push(%q) = %q, %v; want %q, %v", policy, maxLen, x, got, overflow, want, wantOverflow)
+				}
+				if h.Len() != len(oracle) {
+					t.Fatalf("%v, %d: Len() = %d; want %d", policy, maxLen, h.Len(), len(oracle))
+				}
+				if len(oracle) > 0 && h.Peek() != oracle[0] {
+					t.Fatalf("%v, %d: Peek() = %q; want %q", policy, maxLen, h.Peek(), oracle[0])
+				}
+			}
+		}
+	}
+}
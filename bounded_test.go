@@ -0,0 +1,19 @@
+package heap
+
+import "testing"
+
+func TestOverflowPolicyString(t *testing.T) {
+	for _, tc := range []struct {
+		p    OverflowPolicy
+		want string
+	}{
+		{OverflowReject, "OverflowReject"},
+		{OverflowEvictWorst, "OverflowEvictWorst"},
+		{OverflowEvictBest, "OverflowEvictBest"},
+		{OverflowPolicy(5), "OverflowPolicy(5)"},
+	} {
+		if got := tc.p.String(); got != tc.want {
+			t.Errorf("String() = %q; want %q", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package heap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand"
+	"testing"
+)
+
+func (h *BytesFunc) verify(t *testing.T, i int) {
+	t.Helper()
+	n := h.length()
+	j1 := 2*i + 1
+	j2 := 2*i + 2
+	if j1 < n {
+		if h.less(j1, i) {
+			t.Errorf("heap invariant invalidated [%d] = %x > [%d] = %x", i, h.Values[i], j1, h.Values[j1])
+			return
+		}
+		h.verify(t, j1)
+	}
+	if j2 < n {
+		if h.less(j2, i) {
+			t.Errorf("heap invariant invalidated [%d] = %x > [%d] = %x", i, h.Values[i], j1, h.Values[j2])
+			return
+		}
+		h.verify(t, j2)
+	}
+}
+
+// timestampKey returns a key which consists of a user key followed by
+// an 8-byte big endian timestamp.
+func timestampKey(userKey string, ts uint64) []byte {
+	k := make([]byte, len(userKey)+8)
+	copy(k, userKey)
+	binary.BigEndian.PutUint64(k[len(userKey):], ts)
+	return k
+}
+
+// compareTimestampKey orders keys by user key ascending and
+// then by timestamp descending so that the newest version comes first.
+func compareTimestampKey(a, b []byte) int {
+	if c := bytes.Compare(a[:len(a)-8], b[:len(b)-8]); c != 0 {
+		return c
+	}
+	ta := binary.BigEndian.Uint64(a[len(a)-8:])
+	tb := binary.BigEndian.Uint64(b[len(b)-8:])
+	switch {
+	case ta > tb:
+		return -1
+	case ta < tb:
+		return 1
+	}
+	return 0
+}
+
+func TestBytesFunc(t *testing.T) {
+	want := [][]byte{
+		timestampKey("a", 3),
+		timestampKey("a", 2),
+		timestampKey("a", 1),
+		timestampKey("ab", 9),
+		timestampKey("ab", 1),
+		timestampKey("b", 5),
+	}
+
+	h := &BytesFunc{Cmp: compareTimestampKey}
+	for _, i := range rand.Perm(len(want)) {
+		h.Push(want[i])
+		h.verify(t, 0)
+	}
+	for i := 0; h.length() > 0; i++ {
+		x := h.Pop()
+		h.verify(t, 0)
+		if !bytes.Equal(x, want[i]) {
+			t.Errorf("%d.th pop got %x; want %x", i, x, want[i])
+		}
+	}
+
+	h.Values = nil
+	for _, i := range rand.Perm(len(want)) {
+		h.Values = append(h.Values, want[i])
+	}
+	h.Init()
+	h.verify(t, 0)
+	for i := 0; h.length() > 0; i++ {
+		x := h.Pop()
+		if !bytes.Equal(x, want[i]) {
+			t.Errorf("%d.th pop after Init got %x; want %x", i, x, want[i])
+		}
+	}
+}
+
+func TestBytesFuncMax(t *testing.T) {
+	h := &BytesFunc{Cmp: func(a, b []byte) int { return -bytes.Compare(a, b) }}
+	for i := 0; i < 20; i++ {
+		h.Push([]byte(toHex(uint64(i))))
+		h.verify(t, 0)
+	}
+	for i := 19; h.length() > 0; i-- {
+		x := h.Pop()
+		h.verify(t, 0)
+		if string(x) != toHex(uint64(i)) {
+			t.Errorf("pop got %s; want %s", x, toHex(uint64(i)))
+		}
+	}
+}
+
+func TestBytesFuncRemove(t *testing.T) {
+	N := 10
+
+	h := &BytesFunc{Cmp: bytes.Compare}
+	for i := 0; i < N; i++ {
+		h.push([]byte(toHex(uint64(i))))
+	}
+	h.verify(t, 0)
+
+	m := make(map[string]bool)
+	for h.length() > 0 {
+		m[string(h.Remove((h.length()-1)/2))] = true
+		h.verify(t, 0)
+	}
+
+	if len(m) != N {
+		t.Errorf("len(m) = %d; want %d", len(m), N)
+	}
+	for i := 0; i < len(m); i++ {
+		k := toHex(uint64(i))
+		if !m[k] {
+			t.Errorf("m[%s] doesn't exist", k)
+		}
+	}
+}
+
+func TestBytesFuncFix(t *testing.T) {
+	h := &BytesFunc{Cmp: bytes.Compare}
+	h.verify(t, 0)
+
+	for i := 200; i > 0; i -= 10 {
+		h.Push([]byte(toHex(uint64(i))))
+	}
+	h.verify(t, 0)
+
+	if string(h.Values[0]) != toHex(10) {
+		t.Fatalf("Expected head to be 10, was %s", h.Values[0])
+	}
+	h.Values[0] = []byte(toHex(210))
+	h.Fix(0)
+	h.verify(t, 0)
+
+	for i := 100; i > 0; i-- {
+		elem := rand.Intn(h.length())
+		if i&1 == 0 {
+			h.Values[elem] = []byte(toHex(fromHex(t, string(h.Values[elem])) * 2))
+		} else {
+			h.Values[elem] = []byte(toHex(fromHex(t, string(h.Values[elem])) / 2))
+		}
+		h.Fix(elem)
+		h.verify(t, 0)
+	}
+}
@@ -0,0 +1,79 @@
+package concurrent
+
+import (
+	"cmp"
+	"context"
+
+	"github.com/hnakamur/heap"
+)
+
+// Merge returns a channel which emits the values received from ins in
+// ascending order, provided that the values of each input channel are
+// in ascending order. Equal values are emitted in the order of ins.
+//
+// Since an input may send a smaller value at any time, Merge waits until
+// every open input has a value or is closed before it emits the minimum.
+// A closed input is no longer waited for. The returned channel is closed
+// after all inputs are closed and their values are emitted, or when ctx
+// is canceled.
+func Merge[T cmp.Ordered](ctx context.Context, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		h := heap.Func[mergeItem[T]]{
+			Values: make([]mergeItem[T], 0, len(ins)),
+			Cmp: func(a, b mergeItem[T]) int {
+				if c := cmp.Compare(a.v, b.v); c != 0 {
+					return c
+				}
+				return cmp.Compare(a.src, b.src)
+			},
+		}
+		for i, in := range ins {
+			v, ok, err := receive(ctx, in)
+			if err != nil {
+				return
+			}
+			if ok {
+				h.Values = append(h.Values, mergeItem[T]{v: v, src: i})
+			}
+		}
+		h.Init()
+
+		for len(h.Values) > 0 {
+			top := h.Values[0]
+			select {
+			case out <- top.v:
+			case <-ctx.Done():
+				return
+			}
+			v, ok, err := receive(ctx, ins[top.src])
+			if err != nil {
+				return
+			}
+			if ok {
+				h.Values[0].v = v
+				h.Fix(0)
+			} else {
+				h.Pop()
+			}
+		}
+	}()
+	return out
+}
+
+type mergeItem[T any] struct {
+	v   T
+	src int
+}
+
+// receive receives a value from in. ok is false if in is closed.
+// err is non-nil if ctx is canceled before a value is received.
+func receive[T any](ctx context.Context, in <-chan T) (v T, ok bool, err error) {
+	select {
+	case v, ok = <-in:
+		return v, ok, nil
+	case <-ctx.Done():
+		return v, false, ctx.Err()
+	}
+}
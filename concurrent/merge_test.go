@@ -0,0 +1,86 @@
+package concurrent
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMerge(t *testing.T) {
+	const n = 8
+	ins := make([]<-chan int64, n)
+	var want []int64
+	for i := range ins {
+		values := make([]int64, rand.Intn(100))
+		for j := range values {
+			values[j] = rand.Int63n(1000)
+		}
+		slices.Sort(values)
+		want = append(want, values...)
+
+		ch := make(chan int64, rand.Intn(3))
+		go func() {
+			for _, v := range values {
+				ch <- v
+			}
+			close(ch)
+		}()
+		ins[i] = ch
+	}
+	slices.Sort(want)
+
+	var got []int64
+	for v := range Merge(context.Background(), ins...) {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Merge emitted %v; want %v", got, want)
+	}
+}
+
+func TestMergeNoInputs(t *testing.T) {
+	if v, ok := <-Merge[string](context.Background()); ok {
+		t.Errorf("Merge emitted %q; want closed channel", v)
+	}
+}
+
+func TestMergeWaitsForHeads(t *testing.T) {
+	a := make(chan int64, 1)
+	b := make(chan int64, 1)
+	out := Merge(context.Background(), a, b)
+
+	a <- 5
+	select {
+	case v := <-out:
+		t.Fatalf("Merge emitted %d before every input had a value", v)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	b <- 1
+	if v := <-out; v != 1 {
+		t.Errorf("first value = %d; want 1", v)
+	}
+	close(b)
+	if v := <-out; v != 5 {
+		t.Errorf("second value = %d; want 5", v)
+	}
+	close(a)
+	if v, ok := <-out; ok {
+		t.Errorf("Merge emitted %d; want closed channel", v)
+	}
+}
+
+func TestMergeCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	a := make(chan int64, 1)
+	b := make(chan int64)
+	out := Merge(ctx, a, b)
+
+	a <- 1
+	cancel()
+	for v := range out {
+		t.Errorf("Merge emitted %d after cancellation", v)
+	}
+}
@@ -0,0 +1,200 @@
+package concurrent
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hnakamur/heap"
+)
+
+func TestMultiQueueInt64Empty(t *testing.T) {
+	q := NewMultiQueueInt64(0)
+	if got := q.Len(); got != 0 {
+		t.Errorf("Len() = %d; want 0", got)
+	}
+	if x, ok := q.TryPop(); ok {
+		t.Errorf("TryPop() = %d, true; want false", x)
+	}
+	q.Push(-3)
+	if x, ok := q.TryPop(); !ok || x != -3 {
+		t.Errorf("TryPop() = %d, %v; want -3, true", x, ok)
+	}
+	if x, ok := q.TryPop(); ok {
+		t.Errorf("TryPop() = %d, true; want false", x)
+	}
+}
+
+func TestMultiQueueInt64TryPopLocked(t *testing.T) {
+	// TryPop waits for the lock of a non-empty heap instead of spinning
+	// while other goroutines hold the locks.
+	q := NewMultiQueueInt64(4)
+	q.Push(7)
+	for i := range q.queues {
+		q.queues[i].mu.Lock()
+	}
+	done := make(chan int64)
+	go func() {
+		x, ok := q.TryPop()
+		if !ok {
+			t.Error("TryPop() returned false for a non-empty queue")
+		}
+		done <- x
+	}()
+	select {
+	case <-done:
+		t.Fatal("TryPop() returned while all heaps were locked")
+	case <-time.After(10 * time.Millisecond):
+	}
+	for i := range q.queues {
+		q.queues[i].mu.Unlock()
+	}
+	if x := <-done; x != 7 {
+		t.Errorf("TryPop() = %d; want 7", x)
+	}
+}
+
+func TestMultiQueueInt64RankError(t *testing.T) {
+	const m = 8
+	const n = 4000
+	q := NewMultiQueueInt64(m)
+	for _, v := range rand.Perm(n) {
+		q.Push(int64(v))
+	}
+	if got := q.Len(); got != n {
+		t.Fatalf("Len() = %d; want %d", got, n)
+	}
+
+	popped := make([]bool, n)
+	total := 0
+	for i := 0; i < n; i++ {
+		x, ok := q.TryPop()
+		if !ok {
+			t.Fatalf("TryPop() at %d returned false", i)
+		}
+		if popped[x] {
+			t.Fatalf("TryPop() returned %d twice", x)
+		}
+		popped[x] = true
+		for j := int64(0); j < x; j++ {
+			if !popped[j] {
+				total++
+			}
+		}
+	}
+	if _, ok := q.TryPop(); ok {
+		t.Error("TryPop() returned true for an empty queue")
+	}
+	if avg := float64(total) / n; avg > 4*m {
+		t.Errorf("average rank error = %g; want <= %d", avg, 4*m)
+	}
+}
+
+func TestMultiQueueInt64Concurrent(t *testing.T) {
+	const producers = 4
+	const consumers = 4
+	const perProducer = 5000
+	q := NewMultiQueueInt64(0)
+
+	var pwg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		pwg.Add(1)
+		go func(p int) {
+			defer pwg.Done()
+			for i := 0; i < perProducer; i++ {
+				q.Push(int64(p*perProducer + i))
+			}
+		}(p)
+	}
+	done := make(chan struct{})
+	go func() {
+		pwg.Wait()
+		close(done)
+	}()
+
+	results := make([][]int64, consumers)
+	var cwg sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		cwg.Add(1)
+		go func(c int) {
+			defer cwg.Done()
+			for {
+				if x, ok := q.TryPop(); ok {
+					results[c] = append(results[c], x)
+					continue
+				}
+				select {
+				case <-done:
+					// Producers have finished, so drain what is left.
+					for {
+						x, ok := q.TryPop()
+						if !ok {
+							return
+						}
+						results[c] = append(results[c], x)
+					}
+				default:
+				}
+			}
+		}(c)
+	}
+	cwg.Wait()
+
+	seen := make([]bool, producers*perProducer)
+	count := 0
+	for _, r := range results {
+		for _, x := range r {
+			if seen[x] {
+				t.Fatalf("value %d popped twice", x)
+			}
+			seen[x] = true
+			count++
+		}
+	}
+	if count != len(seen) {
+		t.Errorf("popped %d values; want %d", count, len(seen))
+	}
+	if got := q.Len(); got != 0 {
+		t.Errorf("Len() = %d; want 0", got)
+	}
+}
+
+type mutexMinInt64 struct {
+	mu sync.Mutex
+	h  heap.MinInt64
+}
+
+func BenchmarkMultiQueueInt64(b *testing.B) {
+	const prefill = 1 << 16
+	b.Run("multiqueue", func(b *testing.B) {
+		q := NewMultiQueueInt64(0)
+		for i := 0; i < prefill; i++ {
+			q.Push(rand.Int63())
+		}
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				q.Push(rand.Int63())
+				q.TryPop()
+			}
+		})
+	})
+	b.Run("mutex", func(b *testing.B) {
+		var q mutexMinInt64
+		for i := 0; i < prefill; i++ {
+			q.h.Push(rand.Int63())
+		}
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				q.mu.Lock()
+				q.h.Push(rand.Int63())
+				q.mu.Unlock()
+				q.mu.Lock()
+				q.h.Pop()
+				q.mu.Unlock()
+			}
+		})
+	})
+}
@@ -0,0 +1,138 @@
+package concurrent
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hnakamur/heap"
+)
+
+// MultiQueueUint64 is a relaxed concurrent priority queue for getting
+// a small uint64 value. It is a MultiQueue, which consists of several
+// heaps each protected by its own mutex. Push adds an element to a random
+// heap, and TryPop removes the smaller of the minimums of two random heaps.
+//
+// TryPop does not always return the minimum element. With m heaps,
+// the expected rank error, which is the number of elements in the queue
+// less than the returned element, is O(m), and it is O(m log m) with
+// high probability.
+type MultiQueueUint64 struct {
+	queues []lockedUint64
+}
+
+type lockedUint64 struct {
+	mu  sync.Mutex
+	h   heap.MinUint64
+	min atomic.Uint64 // minimum of h, or math.MaxUint64 if h is empty
+	n   atomic.Int64  // length of h
+	_   [64]byte      // padding to avoid false sharing
+}
+
+// NewMultiQueueUint64 returns an empty queue with n heaps.
+// If n is less than 2, twice the value of runtime.GOMAXPROCS is used.
+func NewMultiQueueUint64(n int) *MultiQueueUint64 {
+	if n < 2 {
+		n = max(2*runtime.GOMAXPROCS(0), 2)
+	}
+	q := &MultiQueueUint64{queues: make([]lockedUint64, n)}
+	for i := range q.queues {
+		q.queues[i].min.Store(math.MaxUint64)
+	}
+	return q
+}
+
+// Len returns the number of elements in the queue. The result may be
+// inaccurate when other goroutines are pushing or popping elements.
+func (q *MultiQueueUint64) Len() int {
+	n := 0
+	for i := range q.queues {
+		n += int(q.queues[i].n.Load())
+	}
+	return n
+}
+
+// Push pushes the element x onto the queue.
+func (q *MultiQueueUint64) Push(x uint64) {
+	l := &q.queues[rand.Intn(len(q.queues))]
+	for attempt := 0; !l.mu.TryLock(); attempt++ {
+		if attempt == len(q.queues) {
+			l.mu.Lock()
+			break
+		}
+		// Another goroutine holds the heap, so try another one.
+		l = &q.queues[rand.Intn(len(q.queues))]
+	}
+	l.h.Push(x)
+	l.update()
+	l.mu.Unlock()
+}
+
+// TryPop removes and returns a small element from the queue.
+// ok is false if the queue is empty.
+//
+// TryPop does not spin: if the random choices fail because the chosen heaps
+// are empty or locked by other goroutines, it visits the heaps in turn and
+// waits for the lock of a non-empty one.
+func (q *MultiQueueUint64) TryPop() (x uint64, ok bool) {
+	for attempt := 0; attempt < len(q.queues); attempt++ {
+		l := &q.queues[rand.Intn(len(q.queues))]
+		if l2 := &q.queues[rand.Intn(len(q.queues))]; l2.less(l) {
+			l = l2
+		}
+		if l.n.Load() == 0 || !l.mu.TryLock() {
+			continue
+		}
+		if x, ok = l.pop(); ok {
+			return x, true
+		}
+	}
+
+	// Random choices have failed, so visit the heaps one by one from
+	// a random one before reporting that the queue is empty.
+	start := rand.Intn(len(q.queues))
+	for i := range q.queues {
+		l := &q.queues[(start+i)%len(q.queues)]
+		if l.n.Load() == 0 {
+			continue
+		}
+		l.mu.Lock()
+		if x, ok = l.pop(); ok {
+			return x, true
+		}
+	}
+	return 0, false
+}
+
+// less reports whether l should be popped from rather than m.
+// Empty heaps are never chosen over non-empty ones.
+func (l *lockedUint64) less(m *lockedUint64) bool {
+	if l.n.Load() == 0 {
+		return false
+	}
+	return m.n.Load() == 0 || l.min.Load() < m.min.Load()
+}
+
+// pop pops the minimum element if l is not empty, and unlocks l.
+// l.mu must be held.
+func (l *lockedUint64) pop() (x uint64, ok bool) {
+	defer l.mu.Unlock()
+	if len(l.h) == 0 {
+		return x, false
+	}
+	x = l.h.Pop()
+	l.update()
+	return x, true
+}
+
+// update updates the cached minimum and length. l.mu must be held.
+func (l *lockedUint64) update() {
+	if len(l.h) == 0 {
+		l.min.Store(math.MaxUint64)
+	} else {
+		l.min.Store(l.h[0])
+	}
+	l.n.Store(int64(len(l.h)))
+}
@@ -1,4 +1,5 @@
-// Package heap provides heap operations for string, int64, and uint64.
+// Package heap provides heap operations for string, []byte, int64, uint64,
+// float64, and float32.
 // A heap is a tree with the property that each node is the
 // minimum-valued node in its subtree.
 //
@@ -7,7 +8,46 @@
 // This package is copied from the standard library container/heap
 // and modified for concrete types such as string.
 //
-// Package heap also provides structs MaxStr, MaxInt64, and MaxUint64
-// for maximum versions of heap.
+// Package heap also provides structs MaxStr, MaxBytes, MaxInt64, MaxUint64,
+// MaxFloat64, and MaxFloat32 for maximum versions of heap.
+//
+// The floating-point heaps order NaNs after all other values, so NaNs are
+// popped last from both the minimum and maximum versions. Signed zeros are
+// ordered as -0.0 < +0.0.
+//
+// BytesFunc and StrFunc are heaps of []byte and string values ordered by
+// a user supplied comparator instead of bytes.Compare and strings.Compare.
+// CompareFoldASCII and CompareNatural are comparators for user-facing names.
+// Func is a heap of values of any type ordered by a user supplied comparator.
+// IndexedFunc is a heap of distinct comparable values which keeps the index of
+// each value, so that a value can be updated or deleted without searching for it.
+// PosFunc is a heap which reports the index of each element whenever it moves,
+// so that callers can keep the indexes in slices or in the elements themselves.
+//
+// IntervalStr, IntervalInt64, and IntervalUint64 are interval heaps, which are
+// double-ended priority queues for getting both the minimum and maximum values.
+// BoundedMinInt64, BoundedMaxInt64, and BoundedMinStr hold at most a fixed
+// number of elements in interval heaps and drop the new, worst, or best element
+// on overflow.
+// BlockedMinInt64 and BlockedMinUint64 are 8-ary heaps with a memory layout
+// where each group of siblings spans at most two cache lines.
+//
+// FibHeap is a Fibonacci heap whose elements are referenced by node handles.
+// It supports DecreaseKey in amortized O(1) time. BinomialHeap is a binomial
+// heap with node handles which can be melded in O(log n) time.
+// Leftist is a persistent leftist heap whose Push and Pop return new versions
+// sharing structure with the old ones.
+//
+// KthSmallest, KthLargest, NSmallest, NLargest, and their variants select
+// the smallest or largest elements of a slice without sorting it, with a heap
+// if few elements are selected and with quickselect otherwise.
+//
+// Instrument wraps an array-backed binary heap in an Instrumented heap, which
+// counts operations, comparisons, and swaps and publishes them with expvar.
+// The heaps which are not wrapped have no instrumentation overhead.
+//
+// The Interface methods of the array-backed heaps return adapters to
+// heap.Interface of container/heap and sort.Interface, which share the
+// backing slices with the heaps.
 //
 package heap
@@ -0,0 +1,106 @@
+// Package evict provides an index for cache eviction, which is a heap of
+// cache keys ordered by priorities such as expiry times for TTL caches or
+// access counts for LFU caches.
+package evict
+
+import "github.com/hnakamur/heap"
+
+// Index is a heap of keys ordered by their priorities with a map from the
+// keys to their positions in the heap, so that the priority of a key can be
+// updated and a key can be deleted without knowing its position.
+type Index[K comparable, P any] struct {
+	entries  heap.PosFunc[entry[K, P]]
+	pos      map[K]int
+	capacity int
+}
+
+type entry[K comparable, P any] struct {
+	key  K
+	prio P
+}
+
+// New returns an empty Index which holds at most capacity keys.
+// If capacity is zero, the number of keys is not bounded.
+//
+// cmp returns a negative number when a is less than b, zero when a equals b,
+// and a positive number when a is greater than b, like cmp.Compare.
+// The key with the least priority is evicted first.
+func New[K comparable, P any](capacity int, cmp func(a, b P) int) *Index[K, P] {
+	x := &Index[K, P]{
+		pos:      make(map[K]int),
+		capacity: capacity,
+	}
+	x.entries.Cmp = func(a, b entry[K, P]) int { return cmp(a.prio, b.prio) }
+	x.entries.SetPos = func(e entry[K, P], i int) {
+		if i < 0 {
+			delete(x.pos, e.key)
+		} else {
+			x.pos[e.key] = i
+		}
+	}
+	return x
+}
+
+// Len returns the number of keys in the index.
+func (x *Index[K, P]) Len() int { return len(x.entries.Values) }
+
+// Priority returns the priority of the key. ok is false if the key is
+// not in the index.
+func (x *Index[K, P]) Priority(key K) (prio P, ok bool) {
+	i, ok := x.pos[key]
+	if !ok {
+		return prio, false
+	}
+	return x.entries.Values[i].prio, true
+}
+
+// Touch adds the key with the priority, or updates the priority of the key
+// if it is already in the index. If adding the key exceeds the capacity,
+// the key with the least priority, which may be the added key itself,
+// is evicted and returned with evicted set to true.
+// The complexity is O(log n) where n = x.Len().
+func (x *Index[K, P]) Touch(key K, prio P) (evictedKey K, evictedPrio P, evicted bool) {
+	if i, ok := x.pos[key]; ok {
+		x.entries.Values[i].prio = prio
+		x.entries.Fix(i)
+		return evictedKey, evictedPrio, false
+	}
+	x.entries.Push(entry[K, P]{key: key, prio: prio})
+	if x.capacity > 0 && len(x.entries.Values) > x.capacity {
+		return x.Evict()
+	}
+	return evictedKey, evictedPrio, false
+}
+
+// Peek returns the key with the least priority without removing it.
+// ok is false if the index is empty.
+func (x *Index[K, P]) Peek() (key K, prio P, ok bool) {
+	if len(x.entries.Values) == 0 {
+		return key, prio, false
+	}
+	e := x.entries.Values[0]
+	return e.key, e.prio, true
+}
+
+// Evict removes and returns the key with the least priority.
+// ok is false if the index is empty.
+// The complexity is O(log n) where n = x.Len().
+func (x *Index[K, P]) Evict() (key K, prio P, ok bool) {
+	if len(x.entries.Values) == 0 {
+		return key, prio, false
+	}
+	e := x.entries.Pop()
+	return e.key, e.prio, true
+}
+
+// Delete removes the key from the index. It returns false if the key is
+// not in the index.
+// The complexity is O(log n) where n = x.Len().
+func (x *Index[K, P]) Delete(key K) bool {
+	i, ok := x.pos[key]
+	if !ok {
+		return false
+	}
+	x.entries.Remove(i)
+	return true
+}
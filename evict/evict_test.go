@@ -0,0 +1,179 @@
+package evict
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func (x *Index[K, P]) verify(t *testing.T) {
+	t.Helper()
+	entries := x.entries.Values
+	if len(x.pos) != len(entries) {
+		t.Errorf("len(pos) = %d; want %d", len(x.pos), len(entries))
+	}
+	for i, e := range entries {
+		if x.pos[e.key] != i {
+			t.Errorf("pos[%v] = %d; want %d", e.key, x.pos[e.key], i)
+		}
+		if p := (i - 1) / 2; i > 0 && x.entries.Cmp(e, entries[p]) < 0 {
+			t.Errorf("heap invariant invalidated [%d] = %v > [%d] = %v", p, entries[p].prio, i, e.prio)
+		}
+	}
+}
+
+func TestIndexTTL(t *testing.T) {
+	// The priorities are expiry times.
+	x := New[string](0, cmp.Compare[int64])
+	x.Touch("a", 30)
+	x.Touch("b", 10)
+	x.Touch("c", 20)
+	x.Touch("d", 40)
+	x.Touch("b", 50) // b is refreshed
+	x.verify(t)
+
+	// Evict the keys expired at time 35.
+	var expired []string
+	for {
+		key, at, ok := x.Peek()
+		if !ok || at > 35 {
+			break
+		}
+		x.Evict()
+		expired = append(expired, key)
+	}
+	if len(expired) != 2 || expired[0] != "c" || expired[1] != "a" {
+		t.Errorf("expired = %v; want [c a]", expired)
+	}
+	if p, ok := x.Priority("b"); !ok || p != 50 {
+		t.Errorf("Priority(b) = %d, %v; want 50, true", p, ok)
+	}
+	x.verify(t)
+}
+
+type lfuPriority struct {
+	count      int
+	lastAccess int
+}
+
+func compareLFU(a, b lfuPriority) int {
+	if c := cmp.Compare(a.count, b.count); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.lastAccess, b.lastAccess)
+}
+
+func TestIndexLFU(t *testing.T) {
+	x := New[string](3, compareLFU)
+	access := func(now int, key string) (string, bool) {
+		p, _ := x.Priority(key)
+		evicted, _, ok := x.Touch(key, lfuPriority{count: p.count + 1, lastAccess: now})
+		return evicted, ok
+	}
+
+	for now, key := range []string{"a", "b", "a", "c", "b", "a"} {
+		if evicted, ok := access(now, key); ok {
+			t.Fatalf("access(%s) evicted %s", key, evicted)
+		}
+	}
+	x.verify(t)
+
+	// c is the least frequently used key.
+	if evicted, ok := access(6, "d"); !ok || evicted != "c" {
+		t.Errorf("access(d) evicted %s, %v; want c, true", evicted, ok)
+	}
+	// d and e have the same count, and d is the least recently used key.
+	if evicted, ok := access(7, "e"); !ok || evicted != "d" {
+		t.Errorf("access(e) evicted %s, %v; want d, true", evicted, ok)
+	}
+	if x.Len() != 3 {
+		t.Errorf("Len() = %d; want 3", x.Len())
+	}
+	x.verify(t)
+}
+
+func TestIndexDelete(t *testing.T) {
+	x := New[int](0, cmp.Compare[int])
+	for i := 0; i < 10; i++ {
+		x.Touch(i, i)
+	}
+	for _, key := range []int{0, 5, 9, 3} {
+		if !x.Delete(key) {
+			t.Errorf("Delete(%d) = false; want true", key)
+		}
+		x.verify(t)
+	}
+	if x.Delete(5) {
+		t.Error("Delete(5) of deleted key = true; want false")
+	}
+
+	var got []int
+	for {
+		key, _, ok := x.Evict()
+		if !ok {
+			break
+		}
+		got = append(got, key)
+	}
+	if want := []int{1, 2, 4, 6, 7, 8}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestIndexRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const capacity = 50
+	x := New[int](capacity, cmp.Compare[int])
+	oracle := make(map[int]int)
+
+	minKey := func() (int, int) {
+		first := true
+		var mk, mp int
+		for k, p := range oracle {
+			if first || p < mp {
+				mk, mp, first = k, p, false
+			}
+		}
+		return mk, mp
+	}
+
+	for step := 0; step < 5000; step++ {
+		key := rnd.Intn(100)
+		switch rnd.Intn(4) {
+		case 0, 1:
+			prio := rnd.Int() // distinct priorities with high probability
+			_, inIndex := oracle[key]
+			oracle[key] = prio
+			evicted, _, ok := x.Touch(key, prio)
+			if !inIndex && len(oracle) > capacity {
+				mk, _ := minKey()
+				if !ok || evicted != mk {
+					t.Fatalf("step %d: Touch evicted %d, %v; want %d, true", step, evicted, ok, mk)
+				}
+				delete(oracle, mk)
+			} else if ok {
+				t.Fatalf("step %d: Touch evicted %d", step, evicted)
+			}
+		case 2:
+			_, want := oracle[key]
+			if got := x.Delete(key); got != want {
+				t.Fatalf("step %d: Delete(%d) = %v; want %v", step, key, got, want)
+			}
+			delete(oracle, key)
+		case 3:
+			if len(oracle) == 0 {
+				continue
+			}
+			mk, mp := minKey()
+			if k, p, ok := x.Evict(); !ok || k != mk || p != mp {
+				t.Fatalf("step %d: Evict() = %d, %d, %v; want %d, %d, true", step, k, p, ok, mk, mp)
+			}
+			delete(oracle, mk)
+		}
+		if x.Len() != len(oracle) {
+			t.Fatalf("step %d: Len() = %d; want %d", step, x.Len(), len(oracle))
+		}
+	}
+	x.verify(t)
+}
@@ -0,0 +1,107 @@
+package extsort
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"slices"
+)
+
+// Codec encodes and decodes records of type T in the input, the output,
+// and the run files.
+type Codec[T any] interface {
+	// Encode writes v to w.
+	Encode(w *bufio.Writer, v T) error
+
+	// Decode reads a record from r. It returns io.EOF if there are no more
+	// records, and io.ErrUnexpectedEOF if r ends in the middle of a record.
+	Decode(r *bufio.Reader) (T, error)
+
+	// Size returns the approximate number of bytes which v occupies in
+	// memory. It is used to keep records within Config.MemoryBudget.
+	Size(v T) int
+}
+
+// Int64Codec is a Codec for int64 records encoded as 8-byte big endian values.
+var Int64Codec Codec[int64] = int64Codec{}
+
+// Uint64Codec is a Codec for uint64 records encoded as 8-byte big endian values.
+var Uint64Codec Codec[uint64] = uint64Codec{}
+
+// StringCodec is a Codec for string records encoded as uvarint lengths
+// followed by the bytes of the strings.
+var StringCodec Codec[string] = stringCodec{}
+
+type int64Codec struct{}
+
+func (int64Codec) Encode(w *bufio.Writer, v int64) error {
+	return uint64Codec{}.Encode(w, uint64(v))
+}
+
+func (int64Codec) Decode(r *bufio.Reader) (int64, error) {
+	v, err := uint64Codec{}.Decode(r)
+	return int64(v), err
+}
+
+func (int64Codec) Size(v int64) int { return 8 }
+
+type uint64Codec struct{}
+
+func (uint64Codec) Encode(w *bufio.Writer, v uint64) error {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	_, err := w.Write(b[:])
+	return err
+}
+
+func (uint64Codec) Decode(r *bufio.Reader) (uint64, error) {
+	var b [8]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+func (uint64Codec) Size(v uint64) int { return 8 }
+
+type stringCodec struct{}
+
+func (stringCodec) Encode(w *bufio.Writer, v string) error {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], uint64(len(v)))
+	if _, err := w.Write(b[:n]); err != nil {
+		return err
+	}
+	_, err := w.WriteString(v)
+	return err
+}
+
+func (stringCodec) Decode(r *bufio.Reader) (string, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	}
+	// The length may be corrupt, so the buffer grows as bytes arrive
+	// instead of being allocated for the length at once.
+	b := make([]byte, 0, min(n, stringChunkSize))
+	for uint64(len(b)) < n {
+		m := int(min(n-uint64(len(b)), stringChunkSize))
+		b = slices.Grow(b, m)
+		k, err := io.ReadFull(r, b[len(b):len(b)+m])
+		b = b[:len(b)+k]
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return "", err
+		}
+	}
+	return string(b), nil
+}
+
+// stringChunkSize is the maximum number of bytes by which stringCodec.Decode
+// grows its buffer at once.
+const stringChunkSize = 64 << 10
+
+// Size returns the length of v plus the size of the string header.
+func (stringCodec) Size(v string) int { return len(v) + 16 }
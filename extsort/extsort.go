@@ -0,0 +1,285 @@
+// Package extsort provides an external-memory sort for record files which
+// do not fit in memory.
+//
+// Sorted runs are generated by replacement selection with a heap, so the
+// runs are about twice as long as the memory budget on random input and
+// already sorted input produces a single run. The runs are written to
+// temporary files and then merged with a heap, in several passes if there
+// are more runs than Config.FanIn.
+package extsort
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/hnakamur/heap"
+)
+
+const (
+	defaultMemoryBudget = 64 << 20
+	defaultFanIn        = 64
+)
+
+// Config is the configuration for Sort.
+type Config struct {
+	// MemoryBudget is the maximum number of bytes of records held in memory
+	// during run generation, as measured by Codec.Size.
+	// If MemoryBudget is zero, 64 MiB is used.
+	MemoryBudget int
+
+	// FanIn is the maximum number of runs merged at once, which bounds the
+	// number of files open at the same time. If FanIn is less than 2,
+	// 64 is used.
+	FanIn int
+
+	// TempDir is the directory where run files are created.
+	// If TempDir is empty, the default directory for temporary files is used.
+	TempDir string
+}
+
+// Sort reads records from src with codec, sorts them in ascending order,
+// and writes them to dst with codec. Temporary files created by Sort are
+// removed before Sort returns.
+func Sort[T cmp.Ordered](dst io.Writer, src io.Reader, codec Codec[T], cfg Config) (err error) {
+	if cfg.MemoryBudget <= 0 {
+		cfg.MemoryBudget = defaultMemoryBudget
+	}
+	if cfg.FanIn < 2 {
+		cfg.FanIn = defaultFanIn
+	}
+
+	s := &sorter[T]{codec: codec, cfg: cfg}
+	defer func() {
+		if err2 := s.removeRuns(); err == nil {
+			err = err2
+		}
+	}()
+
+	w := bufio.NewWriter(dst)
+	if err := s.generateRuns(w, bufio.NewReader(src)); err != nil {
+		return err
+	}
+	if len(s.runs) > 0 {
+		if err := s.mergeRuns(w); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
+
+type sorter[T cmp.Ordered] struct {
+	codec Codec[T]
+	cfg   Config
+	runs  []string
+}
+
+// runItem is a record in the heap for run generation.
+type runItem[T cmp.Ordered] struct {
+	run int
+	v   T
+}
+
+func compareRunItem[T cmp.Ordered](a, b runItem[T]) int {
+	if c := cmp.Compare(a.run, b.run); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.v, b.v)
+}
+
+// generateRuns writes sorted runs to temporary files with replacement
+// selection. A record which is less than the last written record cannot be
+// appended to the current run, so it is pushed with the next run number.
+// If all records fit in the memory budget, they are written directly to w
+// without creating any run files.
+func (s *sorter[T]) generateRuns(w *bufio.Writer, r *bufio.Reader) error {
+	h := &heap.Func[runItem[T]]{Cmp: compareRunItem[T]}
+	used := 0
+	more := true
+	// fill reads records until the memory budget is used up.
+	// last is the record written last to the current run, or nil if
+	// no record has been written yet.
+	fill := func(run int, last *T) error {
+		for more && (used < s.cfg.MemoryBudget || len(h.Values) == 0) {
+			v, err := s.codec.Decode(r)
+			if err == io.EOF {
+				more = false
+				break
+			} else if err != nil {
+				return err
+			}
+			if last != nil && v < *last {
+				h.Push(runItem[T]{run: run + 1, v: v})
+			} else {
+				h.Push(runItem[T]{run: run, v: v})
+			}
+			used += s.codec.Size(v)
+		}
+		return nil
+	}
+
+	if err := fill(0, nil); err != nil {
+		return err
+	}
+	if !more {
+		for len(h.Values) > 0 {
+			if err := s.codec.Encode(w, h.Pop().v); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var (
+		f   *os.File
+		rw  *bufio.Writer
+		run = -1
+	)
+	for len(h.Values) > 0 {
+		item := h.Pop()
+		used -= s.codec.Size(item.v)
+		if item.run != run {
+			if f != nil {
+				if err := closeRun(f, rw); err != nil {
+					return err
+				}
+			}
+			var err error
+			if f, err = s.createRun(); err != nil {
+				return err
+			}
+			rw = bufio.NewWriter(f)
+			run = item.run
+		}
+		if err := s.codec.Encode(rw, item.v); err != nil {
+			f.Close()
+			return err
+		}
+		if err := fill(run, &item.v); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return closeRun(f, rw)
+}
+
+func (s *sorter[T]) createRun() (*os.File, error) {
+	f, err := os.CreateTemp(s.cfg.TempDir, "extsort-*.run")
+	if err != nil {
+		return nil, err
+	}
+	s.runs = append(s.runs, f.Name())
+	return f, nil
+}
+
+func closeRun(f *os.File, w *bufio.Writer) error {
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// mergeRuns merges runs into new runs FanIn at a time until at most FanIn
+// runs remain, and then merges the remaining runs into w.
+func (s *sorter[T]) mergeRuns(w *bufio.Writer) error {
+	for len(s.runs) > s.cfg.FanIn {
+		srcs := s.runs[:s.cfg.FanIn]
+		f, err := s.createRun()
+		if err != nil {
+			return err
+		}
+		rw := bufio.NewWriter(f)
+		if err := s.merge(rw, srcs); err != nil {
+			f.Close()
+			return err
+		}
+		if err := closeRun(f, rw); err != nil {
+			return err
+		}
+		if err := removeFiles(srcs); err != nil {
+			return err
+		}
+		s.runs = s.runs[s.cfg.FanIn:]
+	}
+	return s.merge(w, s.runs)
+}
+
+// mergeItem is a record in the heap for merging runs.
+type mergeItem[T cmp.Ordered] struct {
+	v   T
+	src int
+}
+
+func compareMergeItem[T cmp.Ordered](a, b mergeItem[T]) int {
+	if c := cmp.Compare(a.v, b.v); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.src, b.src)
+}
+
+// merge merges the sorted run files names into w.
+func (s *sorter[T]) merge(w *bufio.Writer, names []string) error {
+	files := make([]*os.File, 0, len(names))
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	readers := make([]*bufio.Reader, len(names))
+	h := &heap.Func[mergeItem[T]]{
+		Values: make([]mergeItem[T], 0, len(names)),
+		Cmp:    compareMergeItem[T],
+	}
+	for i, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		files = append(files, f)
+		readers[i] = bufio.NewReader(f)
+		v, err := s.codec.Decode(readers[i])
+		if err == io.EOF {
+			continue
+		} else if err != nil {
+			return err
+		}
+		h.Values = append(h.Values, mergeItem[T]{v: v, src: i})
+	}
+	h.Init()
+
+	for len(h.Values) > 0 {
+		item := &h.Values[0]
+		if err := s.codec.Encode(w, item.v); err != nil {
+			return err
+		}
+		v, err := s.codec.Decode(readers[item.src])
+		if err == io.EOF {
+			h.Pop()
+			continue
+		} else if err != nil {
+			return err
+		}
+		item.v = v
+		h.Fix(0)
+	}
+	return nil
+}
+
+func (s *sorter[T]) removeRuns() error {
+	err := removeFiles(s.runs)
+	s.runs = nil
+	return err
+}
+
+func removeFiles(names []string) error {
+	var errs []error
+	for _, name := range names {
+		if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,206 @@
+package extsort
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func encodeRecords[T any](t *testing.T, codec Codec[T], values []T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	for _, v := range values {
+		if err := codec.Encode(w, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func decodeRecords[T any](t *testing.T, codec Codec[T], b []byte) []T {
+	t.Helper()
+	r := bufio.NewReader(bytes.NewReader(b))
+	var values []T
+	for {
+		v, err := codec.Decode(r)
+		if err == io.EOF {
+			return values
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		values = append(values, v)
+	}
+}
+
+func testSort[T cmp.Ordered](t *testing.T, codec Codec[T], values []T, cfg Config) {
+	t.Helper()
+	cfg.TempDir = t.TempDir()
+
+	var dst bytes.Buffer
+	src := bytes.NewReader(encodeRecords(t, codec, values))
+	if err := Sort(&dst, src, codec, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	want := slices.Clone(values)
+	slices.Sort(want)
+	got := decodeRecords(t, codec, dst.Bytes())
+	if !slices.Equal(got, want) {
+		t.Errorf("sorted records mismatch, got %d records, want %d records", len(got), len(want))
+	}
+
+	entries, err := os.ReadDir(cfg.TempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("%d temporary files are left", len(entries))
+	}
+}
+
+func TestSortInt64(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	values := make([]int64, 10000)
+	for i := range values {
+		values[i] = rnd.Int63() - rnd.Int63()
+	}
+
+	testCases := []struct {
+		name   string
+		values []int64
+		cfg    Config
+	}{
+		{name: "InMemory", values: values, cfg: Config{}},
+		{name: "SinglePass", values: values, cfg: Config{MemoryBudget: 8 * 500}},
+		{name: "MultiPass", values: values, cfg: Config{MemoryBudget: 8 * 100, FanIn: 3}},
+		{name: "OneRecord", values: values[:500], cfg: Config{MemoryBudget: 1, FanIn: 2}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testSort(t, Int64Codec, tc.values, tc.cfg)
+		})
+	}
+}
+
+func TestSortUint64(t *testing.T) {
+	rnd := rand.New(rand.NewSource(2))
+	values := make([]uint64, 10000)
+	for i := range values {
+		values[i] = rnd.Uint64() % 1000 // many duplicates
+	}
+	testSort(t, Uint64Codec, values, Config{MemoryBudget: 8 * 100, FanIn: 4})
+}
+
+func TestSortString(t *testing.T) {
+	rnd := rand.New(rand.NewSource(3))
+	values := make([]string, 5000)
+	for i := range values {
+		b := make([]byte, rnd.Intn(20))
+		rnd.Read(b)
+		values[i] = hex.EncodeToString(b)
+	}
+	testSort(t, StringCodec, values, Config{MemoryBudget: 4096, FanIn: 5})
+}
+
+func TestSortEmpty(t *testing.T) {
+	testSort(t, Int64Codec, nil, Config{MemoryBudget: 8})
+}
+
+func TestSortReplacementSelection(t *testing.T) {
+	const n = 1000
+	sorted := make([]int64, n)
+	for i := range sorted {
+		sorted[i] = int64(i)
+	}
+	random := slices.Clone(sorted)
+	rand.New(rand.NewSource(4)).Shuffle(n, func(i, j int) {
+		random[i], random[j] = random[j], random[i]
+	})
+
+	testCases := []struct {
+		name    string
+		values  []int64
+		maxRuns int
+	}{
+		// Already sorted input is written as a single run.
+		{name: "Sorted", values: sorted, maxRuns: 1},
+		// Runs on random input are about twice as long as the memory budget.
+		{name: "Random", values: random, maxRuns: n / 100 / 2 * 3 / 2},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &sorter[int64]{
+				codec: Int64Codec,
+				cfg:   Config{MemoryBudget: 8 * 100, TempDir: t.TempDir()},
+			}
+			defer s.removeRuns()
+
+			src := bufio.NewReader(bytes.NewReader(encodeRecords(t, Int64Codec, tc.values)))
+			if err := s.generateRuns(bufio.NewWriter(io.Discard), src); err != nil {
+				t.Fatal(err)
+			}
+			if len(s.runs) == 0 || len(s.runs) > tc.maxRuns {
+				t.Errorf("got %d runs; want 1 to %d runs", len(s.runs), tc.maxRuns)
+			}
+			for _, name := range s.runs {
+				b, err := os.ReadFile(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if run := decodeRecords(t, Int64Codec, b); !slices.IsSorted(run) {
+					t.Errorf("run %s is not sorted", name)
+				}
+			}
+		})
+	}
+}
+
+func TestSortTruncatedInput(t *testing.T) {
+	b := encodeRecords(t, Int64Codec, []int64{3, 1, 2})
+	err := Sort(io.Discard, bytes.NewReader(b[:len(b)-1]), Int64Codec, Config{TempDir: t.TempDir()})
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("got error %v; want %v", err, io.ErrUnexpectedEOF)
+	}
+}
+
+func TestStringCodecCorruptLength(t *testing.T) {
+	for _, n := range []uint64{1 << 40, 1<<64 - 1} {
+		var b []byte
+		b = binary.AppendUvarint(b, n)
+		b = append(b, "abc"...)
+		_, err := StringCodec.Decode(bufio.NewReader(bytes.NewReader(b)))
+		if !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("Decode with length %d got error %v; want %v", n, err, io.ErrUnexpectedEOF)
+		}
+	}
+}
+
+func TestStringCodecLongString(t *testing.T) {
+	// A string longer than stringChunkSize is read in several chunks.
+	want := strings.Repeat("0123456789", stringChunkSize/5)
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	if err := StringCodec.Encode(w, want); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := StringCodec.Decode(bufio.NewReader(&buf))
+	if err != nil || got != want {
+		t.Errorf("Decode got a string of length %d, %v; want length %d, nil", len(got), err, len(want))
+	}
+}
@@ -0,0 +1,163 @@
+// Package fairqueue provides a weighted fair queue which shares the
+// dequeue bandwidth among flows in proportion to their weights.
+//
+// Each flow has a FIFO queue of items. An item is tagged with a virtual
+// finish time, which is the virtual start time plus the cost of the item
+// divided by the weight of its flow, and items are dequeued in the order of
+// their finish times with a heap. The virtual time of the queue is the finish
+// time of the item dequeued last (self-clocked fair queuing), and the start
+// time of an item is the later of the virtual time and the finish time of the
+// previous item of its flow. So a flow which has been idle does not accumulate
+// credit for the time it was idle.
+package fairqueue
+
+import (
+	"cmp"
+
+	"github.com/hnakamur/heap"
+)
+
+// scale is the fixed-point scale of virtual times, so that costs divided
+// by weights keep precision. cost*scale must not overflow uint64, so costs
+// must be less than maxCost.
+const (
+	scale   = 1 << 20
+	maxCost = 1 << 44
+)
+
+// Queue is a weighted fair queue of items of type T in flows of type F.
+type Queue[F comparable, T any] struct {
+	flows map[F]*flow[F, T]
+	heads heap.PosFunc[*flow[F, T]] // non-empty flows ordered by their first items
+	vtime uint64                    // virtual finish time of the item dequeued last
+	seq   uint64
+	n     int
+}
+
+type flow[F comparable, T any] struct {
+	id         F
+	weight     uint64
+	lastFinish uint64
+	items      []item[T]
+	seq        uint64 // order in which the flow is pushed to heads
+	pos        int    // index in heads, or -1
+}
+
+type item[T any] struct {
+	value  T
+	finish uint64
+}
+
+// compareHead compares the first items of the flows a and b.
+func compareHead[F comparable, T any](a, b *flow[F, T]) int {
+	if c := cmp.Compare(a.items[0].finish, b.items[0].finish); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.seq, b.seq)
+}
+
+func setHeadPos[F comparable, T any](f *flow[F, T], i int) { f.pos = i }
+
+// New returns an empty Queue.
+func New[F comparable, T any]() *Queue[F, T] {
+	return &Queue[F, T]{
+		flows: make(map[F]*flow[F, T]),
+		heads: heap.PosFunc[*flow[F, T]]{Cmp: compareHead[F, T], SetPos: setHeadPos[F, T]},
+	}
+}
+
+// Len returns the number of items in the queue.
+func (q *Queue[F, T]) Len() int { return q.n }
+
+// FlowLen returns the number of items in the flow.
+func (q *Queue[F, T]) FlowLen(id F) int {
+	if f := q.flows[id]; f != nil {
+		return len(f.items)
+	}
+	return 0
+}
+
+// SetWeight sets the weight of the flow. The weight of a flow is 1 until
+// it is set. The new weight applies to the items enqueued after the call.
+// SetWeight panics if weight is zero.
+func (q *Queue[F, T]) SetWeight(id F, weight uint64) {
+	if weight == 0 {
+		panic("fairqueue: zero weight")
+	}
+	q.flow(id).weight = weight
+}
+
+// Enqueue appends the item with the cost to the flow.
+// The cost is the amount of bandwidth the item consumes, such as its size
+// in bytes. Enqueue panics if cost is 2^44 or greater, since virtual times
+// are fixed-point numbers with 20 fractional bits in uint64.
+func (q *Queue[F, T]) Enqueue(id F, cost uint64, value T) {
+	if cost >= maxCost {
+		panic("fairqueue: cost too large")
+	}
+	f := q.flow(id)
+	start := max(q.vtime, f.lastFinish)
+	finish := start + cost*scale/f.weight
+	f.lastFinish = finish
+	f.items = append(f.items, item[T]{value: value, finish: finish})
+	if len(f.items) == 1 {
+		q.pushHead(f)
+	}
+	q.n++
+}
+
+// Dequeue removes and returns the item with the smallest virtual finish
+// time and its flow. ok is false if the queue is empty.
+func (q *Queue[F, T]) Dequeue() (id F, value T, ok bool) {
+	if len(q.heads.Values) == 0 {
+		return id, value, false
+	}
+	f := q.heads.Pop()
+	it := f.items[0]
+	var zero item[T]
+	f.items[0] = zero
+	f.items = f.items[1:]
+	if len(f.items) > 0 {
+		q.pushHead(f)
+	}
+	// Items are dequeued in the order of finish times, and a new item
+	// never finishes before the virtual time, so the virtual time never
+	// moves backwards.
+	q.vtime = it.finish
+	q.n--
+	return f.id, it.value, true
+}
+
+// RemoveFlow removes the flow and its weight, and returns the items
+// left in the flow.
+func (q *Queue[F, T]) RemoveFlow(id F) []T {
+	f := q.flows[id]
+	if f == nil {
+		return nil
+	}
+	delete(q.flows, id)
+	if f.pos >= 0 {
+		q.heads.Remove(f.pos)
+	}
+	values := make([]T, len(f.items))
+	for i, it := range f.items {
+		values[i] = it.value
+	}
+	q.n -= len(f.items)
+	return values
+}
+
+func (q *Queue[F, T]) flow(id F) *flow[F, T] {
+	f := q.flows[id]
+	if f == nil {
+		f = &flow[F, T]{id: id, weight: 1, pos: -1}
+		q.flows[id] = f
+	}
+	return f
+}
+
+func (q *Queue[F, T]) pushHead(f *flow[F, T]) {
+	f.seq = q.seq
+	q.seq++
+	q.heads.Push(f)
+}
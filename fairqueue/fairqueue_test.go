@@ -0,0 +1,169 @@
+package fairqueue
+
+import (
+	"slices"
+	"testing"
+)
+
+// dequeueCosts dequeues n items and returns the sum of the costs
+// of the items for each flow, where the items are their costs.
+func dequeueCosts(t *testing.T, q *Queue[string, uint64], n int) map[string]uint64 {
+	t.Helper()
+	got := make(map[string]uint64)
+	for i := 0; i < n; i++ {
+		id, cost, ok := q.Dequeue()
+		if !ok {
+			t.Fatalf("%d.th Dequeue returned no item", i)
+		}
+		got[id] += cost
+	}
+	return got
+}
+
+func checkShare(t *testing.T, got map[string]uint64, want map[string]uint64, tolerance uint64) {
+	t.Helper()
+	for id, w := range want {
+		if g := got[id]; g+tolerance < w || g > w+tolerance {
+			t.Errorf("flow %s got %d; want %d±%d", id, g, w, tolerance)
+		}
+	}
+}
+
+func TestQueueWeights(t *testing.T) {
+	q := New[string, uint64]()
+	q.SetWeight("b", 2)
+	q.SetWeight("c", 3)
+	for i := 0; i < 1000; i++ {
+		for _, id := range []string{"a", "b", "c"} {
+			q.Enqueue(id, 1, 1)
+		}
+	}
+
+	got := dequeueCosts(t, q, 600)
+	checkShare(t, got, map[string]uint64{"a": 100, "b": 200, "c": 300}, 2)
+	if q.Len() != 3000-600 {
+		t.Errorf("Len() = %d; want %d", q.Len(), 3000-600)
+	}
+}
+
+func TestQueueCosts(t *testing.T) {
+	// Flows with equal weights share the bandwidth equally even if
+	// their items have different costs.
+	q := New[string, uint64]()
+	for i := 0; i < 1000; i++ {
+		q.Enqueue("small", 100, 100)
+		q.Enqueue("large", 1500, 1500)
+	}
+
+	got := dequeueCosts(t, q, 800)
+	if diff := int64(got["small"]) - int64(got["large"]); diff < -1500 || diff > 1500 {
+		t.Errorf("small got %d, large got %d; want equal shares", got["small"], got["large"])
+	}
+}
+
+func TestQueueIdleFlow(t *testing.T) {
+	// A flow which has been idle must not get a burst of bandwidth
+	// for the time it was idle.
+	q := New[string, uint64]()
+	for i := 0; i < 1000; i++ {
+		q.Enqueue("busy", 1, 1)
+	}
+	dequeueCosts(t, q, 500)
+	for i := 0; i < 1000; i++ {
+		q.Enqueue("late", 1, 1)
+	}
+
+	got := dequeueCosts(t, q, 200)
+	checkShare(t, got, map[string]uint64{"busy": 100, "late": 100}, 2)
+}
+
+func TestQueueLateFlowAfterExpensiveItem(t *testing.T) {
+	// Dequeuing an expensive item which started early must not move
+	// the virtual time backwards, or a flow arriving after it would
+	// get all the bandwidth until it catches up.
+	q := New[string, uint64]()
+	q.Enqueue("big", 100, 100)
+	for i := 0; i < 200; i++ {
+		q.Enqueue("small", 1, 1)
+	}
+	for {
+		id, _, ok := q.Dequeue()
+		if !ok {
+			t.Fatal("big was never dequeued")
+		}
+		if id == "big" {
+			break
+		}
+	}
+	for i := 0; i < 200; i++ {
+		q.Enqueue("late", 1, 1)
+	}
+
+	got := dequeueCosts(t, q, 60)
+	checkShare(t, got, map[string]uint64{"small": 30, "late": 30}, 2)
+}
+
+func TestQueueFIFO(t *testing.T) {
+	q := New[int, int]()
+	for i := 0; i < 10; i++ {
+		q.Enqueue(i%2, 1, i)
+	}
+	got := make(map[int][]int)
+	for q.Len() > 0 {
+		id, v, _ := q.Dequeue()
+		got[id] = append(got[id], v)
+	}
+	if want := []int{0, 2, 4, 6, 8}; !slices.Equal(got[0], want) {
+		t.Errorf("flow 0 got %v; want %v", got[0], want)
+	}
+	if want := []int{1, 3, 5, 7, 9}; !slices.Equal(got[1], want) {
+		t.Errorf("flow 1 got %v; want %v", got[1], want)
+	}
+	if _, _, ok := q.Dequeue(); ok {
+		t.Error("Dequeue on empty queue returned an item")
+	}
+}
+
+func TestQueueRemoveFlow(t *testing.T) {
+	q := New[string, int]()
+	q.SetWeight("a", 5)
+	for i := 0; i < 3; i++ {
+		q.Enqueue("a", 1, i)
+		q.Enqueue("b", 1, 10+i)
+	}
+
+	if got := q.RemoveFlow("a"); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("RemoveFlow got %v; want [0 1 2]", got)
+	}
+	if q.RemoveFlow("a") != nil {
+		t.Error("RemoveFlow of removed flow returned items")
+	}
+	if q.Len() != 3 || q.FlowLen("a") != 0 || q.FlowLen("b") != 3 {
+		t.Errorf("Len() = %d, FlowLen(a) = %d, FlowLen(b) = %d; want 3, 0, 3", q.Len(), q.FlowLen("a"), q.FlowLen("b"))
+	}
+	if len(q.heads.Values) != 1 {
+		t.Errorf("len(heads) = %d after RemoveFlow; want 1", len(q.heads.Values))
+	}
+
+	// A flow with the same id is a new flow with the default weight.
+	q.Enqueue("a", 1, 3)
+	var got []int
+	for q.Len() > 0 {
+		_, v, _ := q.Dequeue()
+		got = append(got, v)
+	}
+	if want := []int{10, 3, 11, 12}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+}
+
+func TestQueueEnqueueCostTooLarge(t *testing.T) {
+	q := New[string, int]()
+	q.Enqueue("a", maxCost-1, 0)
+	defer func() {
+		if recover() == nil {
+			t.Error("Enqueue with cost 1<<44 did not panic")
+		}
+	}()
+	q.Enqueue("a", maxCost, 1)
+}
@@ -0,0 +1,235 @@
+package heap
+
+import "cmp"
+
+// FibHeap is a Fibonacci heap for getting the minimum key.
+// Unlike the array-backed heaps, elements are referenced by node handles,
+// so DecreaseKey and Delete do not need the index of an element.
+//
+// The zero value for FibHeap is an empty heap ready to use.
+type FibHeap[K cmp.Ordered, V any] struct {
+	min *FibNode[K, V]
+	n   int
+}
+
+// FibNode is a handle of an element in FibHeap.
+type FibNode[K cmp.Ordered, V any] struct {
+	key    K
+	Value  V
+	parent *FibNode[K, V]
+	child  *FibNode[K, V]
+	left   *FibNode[K, V]
+	right  *FibNode[K, V]
+	degree int
+	mark   bool
+}
+
+// Key returns the key of the node.
+func (x *FibNode[K, V]) Key() K { return x.key }
+
+// Len returns the number of elements in the heap.
+func (h *FibHeap[K, V]) Len() int { return h.n }
+
+// Insert inserts an element with the key and the value, and returns
+// the handle of the element.
+// The amortized complexity is O(1).
+func (h *FibHeap[K, V]) Insert(key K, value V) *FibNode[K, V] {
+	x := &FibNode[K, V]{key: key, Value: value}
+	x.left, x.right = x, x
+	h.addRoot(x)
+	h.n++
+	return x
+}
+
+// Min returns the handle of the element with the minimum key,
+// or nil if the heap is empty.
+// The complexity is O(1).
+func (h *FibHeap[K, V]) Min() *FibNode[K, V] {
+	return h.min
+}
+
+// ExtractMin removes the element with the minimum key from the heap and
+// returns its handle, or nil if the heap is empty.
+// The amortized complexity is O(log n) where n = h.Len().
+func (h *FibHeap[K, V]) ExtractMin() *FibNode[K, V] {
+	z := h.min
+	if z == nil {
+		return nil
+	}
+	for z.child != nil {
+		x := z.child
+		z.child = x.right
+		if z.child == x {
+			z.child = nil
+		}
+		unlink(x)
+		x.parent = nil
+		h.addRoot(x)
+	}
+	if z.right == z {
+		h.min = nil
+	} else {
+		h.min = z.right
+		unlink(z)
+		h.consolidate()
+	}
+	h.n--
+	z.left, z.right, z.degree = z, z, 0
+	return z
+}
+
+// DecreaseKey changes the key of the element x, which must be in the heap,
+// to the key. DecreaseKey panics if the key is greater than the current key.
+// The amortized complexity is O(1).
+func (h *FibHeap[K, V]) DecreaseKey(x *FibNode[K, V], key K) {
+	if key > x.key {
+		panic("heap: DecreaseKey with a greater key")
+	}
+	x.key = key
+	if y := x.parent; y != nil && x.key < y.key {
+		h.cut(x, y)
+		h.cascadingCut(y)
+	}
+	if x.key < h.min.key {
+		h.min = x
+	}
+}
+
+// Delete removes the element x, which must be in the heap, from the heap.
+// The amortized complexity is O(log n) where n = h.Len().
+func (h *FibHeap[K, V]) Delete(x *FibNode[K, V]) {
+	// Move x to the root list as if its key were decreased to
+	// negative infinity, and then extract it.
+	if y := x.parent; y != nil {
+		h.cut(x, y)
+		h.cascadingCut(y)
+	}
+	h.min = x
+	h.ExtractMin()
+}
+
+// Union moves all the elements of other to h. other becomes empty.
+// The complexity is O(1).
+func (h *FibHeap[K, V]) Union(other *FibHeap[K, V]) {
+	if other == h || other.min == nil {
+		return
+	}
+	if h.min == nil {
+		h.min = other.min
+	} else {
+		// Splice the two circular root lists.
+		a, b := h.min.right, other.min.left
+		h.min.right, other.min.left = other.min, h.min
+		a.left, b.right = b, a
+		if other.min.key < h.min.key {
+			h.min = other.min
+		}
+	}
+	h.n += other.n
+	other.min, other.n = nil, 0
+}
+
+// addRoot adds the single node x to the root list.
+func (h *FibHeap[K, V]) addRoot(x *FibNode[K, V]) {
+	if h.min == nil {
+		x.left, x.right = x, x
+		h.min = x
+		return
+	}
+	insertAfter(h.min, x)
+	if x.key < h.min.key {
+		h.min = x
+	}
+}
+
+// consolidate links the roots of the same degree until all roots have
+// distinct degrees, and then finds the new minimum.
+func (h *FibHeap[K, V]) consolidate() {
+	var a []*FibNode[K, V]
+	var roots []*FibNode[K, V]
+	for w := h.min; ; {
+		roots = append(roots, w)
+		if w = w.right; w == h.min {
+			break
+		}
+	}
+	for _, x := range roots {
+		d := x.degree
+		for {
+			for d >= len(a) {
+				a = append(a, nil)
+			}
+			y := a[d]
+			if y == nil {
+				break
+			}
+			if y.key < x.key {
+				x, y = y, x
+			}
+			h.link(y, x)
+			a[d] = nil
+			d++
+		}
+		a[d] = x
+	}
+	h.min = nil
+	for _, x := range a {
+		if x != nil && (h.min == nil || x.key < h.min.key) {
+			h.min = x
+		}
+	}
+}
+
+// link makes the root y a child of the root x.
+func (h *FibHeap[K, V]) link(y, x *FibNode[K, V]) {
+	unlink(y)
+	y.parent = x
+	if x.child == nil {
+		y.left, y.right = y, y
+		x.child = y
+	} else {
+		insertAfter(x.child, y)
+	}
+	x.degree++
+	y.mark = false
+}
+
+// cut moves x from the child list of y to the root list.
+func (h *FibHeap[K, V]) cut(x, y *FibNode[K, V]) {
+	if x.right == x {
+		y.child = nil
+	} else {
+		if y.child == x {
+			y.child = x.right
+		}
+		unlink(x)
+	}
+	y.degree--
+	x.parent = nil
+	x.mark = false
+	insertAfter(h.min, x)
+}
+
+func (h *FibHeap[K, V]) cascadingCut(y *FibNode[K, V]) {
+	for z := y.parent; z != nil; y, z = z, z.parent {
+		if !y.mark {
+			y.mark = true
+			return
+		}
+		h.cut(y, z)
+	}
+}
+
+// insertAfter inserts the single node x after the node at in a circular list.
+func insertAfter[K cmp.Ordered, V any](at, x *FibNode[K, V]) {
+	x.left, x.right = at, at.right
+	at.right.left = x
+	at.right = x
+}
+
+// unlink removes x from its circular list.
+func unlink[K cmp.Ordered, V any](x *FibNode[K, V]) {
+	x.left.right = x.right
+	x.right.left = x.left
+	x.left, x.right = x, x
+}
@@ -0,0 +1,201 @@
+package heap
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func (h *FibHeap[K, V]) verify(t *testing.T) {
+	t.Helper()
+	if h.min == nil {
+		if h.n != 0 {
+			t.Errorf("min is nil but n = %d", h.n)
+		}
+		return
+	}
+	n := 0
+	var walk func(first, parent *FibNode[K, V]) int
+	walk = func(first, parent *FibNode[K, V]) int {
+		count := 0
+		x := first
+		for {
+			count++
+			n++
+			if x.parent != parent {
+				t.Errorf("node %v has wrong parent", x.key)
+			}
+			if x.right.left != x || x.left.right != x {
+				t.Errorf("node %v has broken sibling links", x.key)
+			}
+			if parent != nil && x.key < parent.key {
+				t.Errorf("heap invariant invalidated %v > %v", parent.key, x.key)
+			}
+			if parent == nil && x.key < h.min.key {
+				t.Errorf("min %v is greater than root %v", h.min.key, x.key)
+			}
+			if x.child != nil {
+				if d := walk(x.child, x); d != x.degree {
+					t.Errorf("node %v has degree %d; want %d", x.key, x.degree, d)
+				}
+			} else if x.degree != 0 {
+				t.Errorf("node %v has degree %d; want 0", x.key, x.degree)
+			}
+			if x = x.right; x == first {
+				return count
+			}
+		}
+	}
+	walk(h.min, nil)
+	if n != h.n {
+		t.Errorf("heap has %d nodes; want %d", n, h.n)
+	}
+}
+
+func TestFibHeap(t *testing.T) {
+	h := new(FibHeap[int64, int])
+	h.verify(t)
+	if h.Min() != nil || h.ExtractMin() != nil {
+		t.Fatal("empty heap has an element")
+	}
+
+	for i := 20; i > 10; i-- {
+		h.Insert(int64(i), i)
+	}
+	h.verify(t)
+
+	for i := 10; i > 0; i-- {
+		h.Insert(int64(i), i)
+		h.verify(t)
+	}
+
+	for i := 1; h.Len() > 0; i++ {
+		if x := h.Min(); x.Key() != int64(i) {
+			t.Errorf("%d.th min got %d; want %d", i, x.Key(), int64(i))
+		}
+		x := h.ExtractMin()
+		if i < 20 {
+			h.Insert(int64(20+i), 20+i)
+		}
+		h.verify(t)
+		if x.Key() != int64(i) || x.Value != i {
+			t.Errorf("%d.th extract got %d, %d; want %d, %d", i, x.Key(), x.Value, int64(i), i)
+		}
+	}
+}
+
+func TestFibHeapDecreaseKeyPanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("DecreaseKey with a greater key did not panic")
+		}
+	}()
+	h := new(FibHeap[int64, struct{}])
+	x := h.Insert(1, struct{}{})
+	h.DecreaseKey(x, 2)
+}
+
+// TestFibHeapRandom runs random operations on FibHeap and MinInt64 as an oracle.
+func TestFibHeapRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	h := new(FibHeap[int64, struct{}])
+	var nodes []*FibNode[int64, struct{}]
+	oracle := new(MinInt64)
+
+	// oracleIndex returns the index of an element with the key in oracle.
+	oracleIndex := func(key int64) int {
+		for i, v := range *oracle {
+			if v == key {
+				return i
+			}
+		}
+		t.Fatalf("key %d not found in oracle", key)
+		return -1
+	}
+	removeNode := func(i int) {
+		nodes[i] = nodes[len(nodes)-1]
+		nodes = nodes[:len(nodes)-1]
+	}
+
+	for step := 0; step < 20000; step++ {
+		switch op := rnd.Intn(10); {
+		case op < 4 || len(nodes) == 0:
+			key := rnd.Int63n(1000)
+			nodes = append(nodes, h.Insert(key, struct{}{}))
+			oracle.Push(key)
+		case op < 6:
+			x := h.ExtractMin()
+			if want := oracle.Pop(); x.Key() != want {
+				t.Fatalf("step %d: ExtractMin got %d; want %d", step, x.Key(), want)
+			}
+			for i, y := range nodes {
+				if y == x {
+					removeNode(i)
+					break
+				}
+			}
+		case op < 8:
+			x := nodes[rnd.Intn(len(nodes))]
+			key := x.Key() - rnd.Int63n(100)
+			i := oracleIndex(x.Key())
+			h.DecreaseKey(x, key)
+			(*oracle)[i] = key
+			oracle.Fix(i)
+		case op < 9:
+			i := rnd.Intn(len(nodes))
+			x := nodes[i]
+			h.Delete(x)
+			oracle.Remove(oracleIndex(x.Key()))
+			removeNode(i)
+		default:
+			other := new(FibHeap[int64, struct{}])
+			for i := rnd.Intn(10); i > 0; i-- {
+				key := rnd.Int63n(1000)
+				nodes = append(nodes, other.Insert(key, struct{}{}))
+				oracle.Push(key)
+			}
+			h.Union(other)
+			if other.Len() != 0 || other.Min() != nil {
+				t.Fatalf("step %d: other is not empty after Union", step)
+			}
+		}
+		if step%100 == 0 {
+			h.verify(t)
+		}
+		if h.Len() != len(*oracle) {
+			t.Fatalf("step %d: Len() = %d; want %d", step, h.Len(), len(*oracle))
+		}
+		if h.Len() > 0 && h.Min().Key() != (*oracle)[0] {
+			t.Fatalf("step %d: Min() = %d; want %d", step, h.Min().Key(), (*oracle)[0])
+		}
+	}
+
+	for h.Len() > 0 {
+		if x, want := h.ExtractMin(), oracle.Pop(); x.Key() != want {
+			t.Fatalf("ExtractMin got %d; want %d", x.Key(), want)
+		}
+	}
+	h.verify(t)
+}
+
+func BenchmarkFibHeapDecreaseKey(b *testing.B) {
+	const n = 10000
+	h := new(FibHeap[int64, struct{}])
+	nodes := make([]*FibNode[int64, struct{}], n)
+	for i := 0; i < b.N; i++ {
+		for j := range nodes {
+			nodes[j] = h.Insert(int64(j), struct{}{})
+		}
+		// Decrease the keys of the nodes from the last one, so that every
+		// DecreaseKey moves a node to the minimum, and extract the minimum
+		// once in every ten DecreaseKey calls.
+		for j := n - 1; j >= 0; j-- {
+			h.DecreaseKey(nodes[j], int64(j-n))
+			if j%10 == 0 {
+				h.ExtractMin()
+			}
+		}
+		for h.Len() > 0 {
+			h.ExtractMin()
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package heap
+
+import "math"
+
+// lessFloat64 reports whether x must be popped before y from MinFloat64.
+// NaNs are ordered after all other values and -0.0 is ordered before +0.0,
+// so the ordering is a strict weak ordering even when NaNs are present.
+func lessFloat64(x, y float64) bool {
+	switch {
+	case math.IsNaN(x):
+		return false
+	case math.IsNaN(y):
+		return true
+	case x == 0 && y == 0:
+		return math.Signbit(x) && !math.Signbit(y)
+	}
+	return x < y
+}
+
+// greaterFloat64 reports whether x must be popped before y from MaxFloat64.
+// NaNs are ordered after all other values and +0.0 is ordered before -0.0.
+func greaterFloat64(x, y float64) bool {
+	switch {
+	case math.IsNaN(x):
+		return false
+	case math.IsNaN(y):
+		return true
+	case x == 0 && y == 0:
+		return !math.Signbit(x) && math.Signbit(y)
+	}
+	return x > y
+}
+
+// lessFloat32 is the float32 version of lessFloat64.
+func lessFloat32(x, y float32) bool {
+	return lessFloat64(float64(x), float64(y))
+}
+
+// greaterFloat32 is the float32 version of greaterFloat64.
+func greaterFloat32(x, y float32) bool {
+	return greaterFloat64(float64(x), float64(y))
+}
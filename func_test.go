@@ -0,0 +1,132 @@
+package heap
+
+import (
+	"cmp"
+	"math/rand"
+	"testing"
+)
+
+func (h *Func[T]) verify(t *testing.T, i int) {
+	t.Helper()
+	n := h.length()
+	j1 := 2*i + 1
+	j2 := 2*i + 2
+	if j1 < n {
+		if h.less(j1, i) {
+			t.Errorf("heap invariant invalidated [%d] = %v > [%d] = %v", i, h.Values[i], j1, h.Values[j1])
+			return
+		}
+		h.verify(t, j1)
+	}
+	if j2 < n {
+		if h.less(j2, i) {
+			t.Errorf("heap invariant invalidated [%d] = %v > [%d] = %v", i, h.Values[i], j1, h.Values[j2])
+			return
+		}
+		h.verify(t, j2)
+	}
+}
+
+type funcTestItem struct {
+	key int
+	src int
+}
+
+func compareFuncTestItem(a, b funcTestItem) int {
+	if c := cmp.Compare(a.key, b.key); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.src, b.src)
+}
+
+func TestFunc(t *testing.T) {
+	h := &Func[funcTestItem]{Cmp: compareFuncTestItem}
+	h.verify(t, 0)
+
+	for i := 20; i > 10; i-- {
+		h.push(funcTestItem{key: i / 2, src: i})
+	}
+	h.Init()
+	h.verify(t, 0)
+
+	for i := 10; i > 0; i-- {
+		h.Push(funcTestItem{key: i / 2, src: i})
+		h.verify(t, 0)
+	}
+
+	for i := 1; h.length() > 0; i++ {
+		x := h.Pop()
+		h.verify(t, 0)
+		if want := (funcTestItem{key: i / 2, src: i}); x != want {
+			t.Errorf("%d.th pop got %v; want %v", i, x, want)
+		}
+	}
+}
+
+func TestFuncMax(t *testing.T) {
+	h := &Func[int]{Cmp: func(a, b int) int { return cmp.Compare(b, a) }}
+	for i := 0; i < 20; i++ {
+		h.Push(i)
+		h.verify(t, 0)
+	}
+	for i := 19; h.length() > 0; i-- {
+		x := h.Pop()
+		h.verify(t, 0)
+		if x != i {
+			t.Errorf("pop got %d; want %d", x, i)
+		}
+	}
+}
+
+func TestFuncRemove(t *testing.T) {
+	N := 10
+
+	h := &Func[int]{Cmp: cmp.Compare[int]}
+	for i := 0; i < N; i++ {
+		h.push(i)
+	}
+	h.verify(t, 0)
+
+	m := make(map[int]bool)
+	for h.length() > 0 {
+		m[h.Remove((h.length()-1)/2)] = true
+		h.verify(t, 0)
+	}
+
+	if len(m) != N {
+		t.Errorf("len(m) = %d; want %d", len(m), N)
+	}
+	for i := 0; i < len(m); i++ {
+		if !m[i] {
+			t.Errorf("m[%d] doesn't exist", i)
+		}
+	}
+}
+
+func TestFuncFix(t *testing.T) {
+	h := &Func[int]{Cmp: cmp.Compare[int]}
+	h.verify(t, 0)
+
+	for i := 200; i > 0; i -= 10 {
+		h.Push(i)
+	}
+	h.verify(t, 0)
+
+	if h.Values[0] != 10 {
+		t.Fatalf("Expected head to be 10, was %d", h.Values[0])
+	}
+	h.Values[0] = 210
+	h.Fix(0)
+	h.verify(t, 0)
+
+	for i := 100; i > 0; i-- {
+		elem := rand.Intn(h.length())
+		if i&1 == 0 {
+			h.Values[elem] *= 2
+		} else {
+			h.Values[elem] /= 2
+		}
+		h.Fix(elem)
+		h.verify(t, 0)
+	}
+}
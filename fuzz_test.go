@@ -0,0 +1,144 @@
+package heap
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+// Operations interpreted by the fuzz targets. Each operation is encoded
+// as a byte followed by the bytes of its operands.
+const (
+	fuzzPush   = iota // value
+	fuzzPop           //
+	fuzzRemove        // index
+	fuzzFix           // index, value
+	fuzzInit          // value, which is appended without keeping the heap invariants
+	fuzzNumOps
+)
+
+// fuzzHeap interprets data as a sequence of operations on a heap of type H
+// and compares the results with a sorted slice. value reads an element from
+// data, and top returns the element of the sorted slice which the heap
+// pops first.
+func fuzzHeap[T cmp.Ordered, H ~[]T, PH interface {
+	*H
+	Init()
+	Push(x T)
+	Pop() T
+	Remove(i int) T
+	Fix(i int)
+	verify(t *testing.T, i int)
+}](t *testing.T, data []byte, value func(r *fuzzReader) T, top func(o fuzzOracle[T]) T) {
+	var a H
+	h := PH(&a)
+	var oracle fuzzOracle[T]
+	r := fuzzReader(data)
+	for r.more() {
+		switch r.op() {
+		case fuzzPush:
+			x := value(&r)
+			h.Push(x)
+			oracle.insert(x)
+		case fuzzPop:
+			if len(a) == 0 {
+				continue
+			}
+			want := top(oracle)
+			if x := h.Pop(); x != want {
+				t.Fatalf("Pop() = %v; want %v", x, want)
+			}
+			oracle.delete(want)
+		case fuzzRemove:
+			if len(a) == 0 {
+				continue
+			}
+			i := r.index(len(a))
+			if x := h.Remove(i); !oracle.delete(x) {
+				t.Fatalf("Remove(%d) = %v, which was not pushed", i, x)
+			}
+		case fuzzFix:
+			if len(a) == 0 {
+				continue
+			}
+			i := r.index(len(a))
+			old, x := a[i], value(&r)
+			if !oracle.delete(old) {
+				t.Fatalf("element %v at %d was not pushed", old, i)
+			}
+			a[i] = x
+			h.Fix(i)
+			oracle.insert(x)
+		case fuzzInit:
+			x := value(&r)
+			a = append(a, x)
+			h.Init()
+			oracle.insert(x)
+		}
+		h.verify(t, 0)
+		if t.Failed() {
+			return
+		}
+		if len(a) != len(oracle) {
+			t.Fatalf("len = %d; want %d", len(a), len(oracle))
+		}
+		if len(a) > 0 && a[0] != top(oracle) {
+			t.Fatalf("top = %v; want %v", a[0], top(oracle))
+		}
+	}
+
+	sorted := slices.Clone([]T(a))
+	slices.Sort(sorted)
+	if !slices.Equal(sorted, []T(oracle)) {
+		t.Fatalf("sorted elements = %v; want %v", sorted, oracle)
+	}
+}
+
+// fuzzReader reads operations and their operands from fuzz data.
+// It returns zeros after the data is exhausted.
+type fuzzReader []byte
+
+func (r *fuzzReader) more() bool { return len(*r) > 0 }
+
+func (r *fuzzReader) byte() byte {
+	if len(*r) == 0 {
+		return 0
+	}
+	b := (*r)[0]
+	*r = (*r)[1:]
+	return b
+}
+
+func (r *fuzzReader) op() int         { return int(r.byte()) % fuzzNumOps }
+func (r *fuzzReader) index(n int) int { return int(r.byte()) % n }
+func (r *fuzzReader) int64() int64    { return int64(int8(r.byte())) }
+func (r *fuzzReader) uint64() uint64  { return uint64(r.byte()) }
+
+// str returns a short string of a few letters, so that equal strings and
+// strings which are prefixes of others are pushed often.
+func (r *fuzzReader) str() string {
+	b := make([]byte, r.byte()%4)
+	for i := range b {
+		b[i] = 'a' + r.byte()%3
+	}
+	return string(b)
+}
+
+// fuzzOracle is a sorted slice which the heaps are compared with.
+type fuzzOracle[T cmp.Ordered] []T
+
+func (o *fuzzOracle[T]) insert(x T) {
+	i, _ := slices.BinarySearch(*o, x)
+	*o = slices.Insert(*o, i, x)
+}
+
+func (o *fuzzOracle[T]) delete(x T) bool {
+	i, found := slices.BinarySearch(*o, x)
+	if found {
+		*o = slices.Delete(*o, i, i+1)
+	}
+	return found
+}
+
+func (o fuzzOracle[T]) min() T { return o[0] }
+func (o fuzzOracle[T]) max() T { return o[len(o)-1] }
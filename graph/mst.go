@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"cmp"
+
+	"github.com/hnakamur/heap"
+)
+
+// Edge is an edge of a graph from the vertex From to the vertex To.
+type Edge[W Weight] struct {
+	From   int
+	To     int
+	Weight W
+}
+
+func compareEdge[W Weight](a, b Edge[W]) int {
+	return cmp.Compare(a.Weight, b.Weight)
+}
+
+// Prim returns the edges of a minimum spanning tree of the undirected graph
+// with n vertices and the total weight of the edges. adj must yield each
+// edge in both directions. If the graph is not connected, Prim returns
+// a minimum spanning forest, which has a tree for each connected component.
+//
+// The edges are returned in the order they are added to the tree, and From
+// of each edge is the vertex already in the tree. The complexity is
+// O(m log m) where m is the number of edges.
+func Prim[W Weight](n int, adj Adjacency[W]) (tree []Edge[W], total W) {
+	inTree := make([]bool, n)
+	h := &heap.Func[Edge[W]]{Cmp: compareEdge[W]}
+	add := func(u int) {
+		inTree[u] = true
+		adj(u, func(v int, w W) {
+			if !inTree[v] {
+				h.Push(Edge[W]{From: u, To: v, Weight: w})
+			}
+		})
+	}
+
+	for root := 0; root < n; root++ {
+		if inTree[root] {
+			continue
+		}
+		add(root)
+		for len(h.Values) > 0 {
+			e := h.Pop()
+			if inTree[e.To] {
+				continue // both ends are already in the tree
+			}
+			tree = append(tree, e)
+			total += e.Weight
+			add(e.To)
+		}
+	}
+	return tree, total
+}
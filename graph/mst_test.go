@@ -0,0 +1,103 @@
+package graph
+
+import (
+	"sort"
+	"testing"
+)
+
+// checkSpanningForest checks that tree is a forest which has a tree for
+// each set of vertices in components.
+func checkSpanningForest[W Weight](t *testing.T, n int, tree []Edge[W], components [][]int) {
+	t.Helper()
+	parent := make([]int, n)
+	for v := range parent {
+		parent[v] = v
+	}
+	var find func(v int) int
+	find = func(v int) int {
+		if parent[v] != v {
+			parent[v] = find(parent[v])
+		}
+		return parent[v]
+	}
+	for _, e := range tree {
+		a, b := find(e.From), find(e.To)
+		if a == b {
+			t.Errorf("edge %v makes a cycle", e)
+		}
+		parent[a] = b
+	}
+	if want := n - len(components); len(tree) != want {
+		t.Errorf("got %d edges; want %d", len(tree), want)
+	}
+	for _, c := range components {
+		for _, v := range c[1:] {
+			if find(v) != find(c[0]) {
+				t.Errorf("vertices %d and %d are not connected", c[0], v)
+			}
+		}
+	}
+}
+
+func TestPrim(t *testing.T) {
+	// The graph in Figure 23.1 of Introduction to Algorithms
+	// with vertices a to i numbered from 0 to 8.
+	edges := []edge[int64]{
+		{0, 1, 4}, {0, 7, 8},
+		{1, 2, 8}, {1, 7, 11},
+		{2, 3, 7}, {2, 5, 4}, {2, 8, 2},
+		{3, 4, 9}, {3, 5, 14},
+		{4, 5, 10},
+		{5, 6, 2},
+		{6, 7, 1}, {6, 8, 6},
+		{7, 8, 7},
+	}
+	tree, total := Prim(9, adjacency(9, undirected(edges)))
+	if total != 37 {
+		t.Errorf("total = %d; want 37", total)
+	}
+	checkSpanningForest(t, 9, tree, [][]int{{0, 1, 2, 3, 4, 5, 6, 7, 8}})
+
+	var sum int64
+	for _, e := range tree {
+		sum += e.Weight
+	}
+	if sum != total {
+		t.Errorf("sum of edge weights = %d; want %d", sum, total)
+	}
+}
+
+func TestPrimFloat64(t *testing.T) {
+	// A square with diagonals, where the three shortest sides form the tree.
+	edges := []edge[float64]{
+		{0, 1, 1.5}, {1, 2, 0.5}, {2, 3, 2.5}, {3, 0, 1.0},
+		{0, 2, 3.0}, {1, 3, 3.0},
+	}
+	tree, total := Prim(4, adjacency(4, undirected(edges)))
+	if total != 3.0 {
+		t.Errorf("total = %g; want 3", total)
+	}
+	checkSpanningForest(t, 4, tree, [][]int{{0, 1, 2, 3}})
+
+	weights := make([]float64, len(tree))
+	for i, e := range tree {
+		weights[i] = e.Weight
+	}
+	sort.Float64s(weights)
+	if want := []float64{0.5, 1.0, 1.5}; len(weights) != 3 || weights[0] != want[0] || weights[1] != want[1] || weights[2] != want[2] {
+		t.Errorf("weights = %v; want %v", weights, want)
+	}
+}
+
+func TestPrimForest(t *testing.T) {
+	// Two triangles and an isolated vertex 6.
+	edges := []edge[int64]{
+		{0, 1, 3}, {1, 2, 1}, {2, 0, 2},
+		{3, 4, 5}, {4, 5, 5}, {5, 3, 4},
+	}
+	tree, total := Prim(7, adjacency(7, undirected(edges)))
+	if total != 3+9 {
+		t.Errorf("total = %d; want %d", total, 3+9)
+	}
+	checkSpanningForest(t, 7, tree, [][]int{{0, 1, 2}, {3, 4, 5}, {6}})
+}
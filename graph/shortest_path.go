@@ -0,0 +1,171 @@
+// Package graph provides graph algorithms built on priority queues:
+// Dijkstra's and A* shortest-path searches and Prim's minimum spanning tree.
+//
+// Vertices are integers in [0, n) and edges are given by an adjacency
+// callback, so that graphs can be searched without being materialized.
+package graph
+
+import (
+	"cmp"
+
+	"github.com/hnakamur/heap"
+)
+
+// Weight is the constraint for edge weights and distances.
+type Weight interface {
+	~int64 | ~float64
+}
+
+// Adjacency calls yield for each edge from the vertex u to the vertex v
+// with the weight w. Weights must not be negative.
+type Adjacency[W Weight] func(u int, yield func(v int, w W))
+
+// ShortestPaths is the result of a single-source or multi-source
+// shortest-path search.
+type ShortestPaths[W Weight] struct {
+	dist    []W
+	pred    []int
+	reached []bool
+}
+
+// Dist returns the distance from the nearest source to the vertex v.
+// ok is false if v is not reachable from any source.
+func (p *ShortestPaths[W]) Dist(v int) (d W, ok bool) {
+	return p.dist[v], p.reached[v]
+}
+
+// Pred returns the predecessor of the vertex v on a shortest path,
+// or -1 if v is a source or v is not reachable.
+func (p *ShortestPaths[W]) Pred(v int) int {
+	return p.pred[v]
+}
+
+// Path returns the vertices on a shortest path from the nearest source
+// to the vertex v, or nil if v is not reachable.
+func (p *ShortestPaths[W]) Path(v int) []int {
+	if !p.reached[v] {
+		return nil
+	}
+	var path []int
+	for ; v >= 0; v = p.pred[v] {
+		path = append(path, v)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Dijkstra returns the shortest paths from the source src to all vertices
+// of the graph with n vertices. Dijkstra panics if a negative weight is found.
+// The complexity is O((n + m) log n) where m is the number of edges.
+func Dijkstra[W Weight](n int, adj Adjacency[W], src int) *ShortestPaths[W] {
+	return MultiSourceDijkstra(n, adj, []int{src})
+}
+
+// MultiSourceDijkstra returns the shortest paths from the nearest of the
+// sources srcs to all vertices of the graph with n vertices.
+// MultiSourceDijkstra panics if a negative weight is found.
+func MultiSourceDijkstra[W Weight](n int, adj Adjacency[W], srcs []int) *ShortestPaths[W] {
+	s := newSearch(n, adj, nil)
+	s.run(srcs, -1)
+	return &s.ShortestPaths
+}
+
+// AStar returns a shortest path from the source src to the destination dst
+// and its distance. ok is false if dst is not reachable from src.
+//
+// The heuristic h must return an estimate of the distance from the vertex v
+// to dst which never exceeds the actual distance. AStar panics if
+// a negative weight is found.
+func AStar[W Weight](n int, adj Adjacency[W], src, dst int, h func(v int) W) (path []int, dist W, ok bool) {
+	return MultiSourceAStar(n, adj, []int{src}, dst, h)
+}
+
+// MultiSourceAStar returns a shortest path from the nearest of the sources
+// srcs to the destination dst and its distance. ok is false if dst is not
+// reachable from any source. The heuristic h is the same as AStar.
+func MultiSourceAStar[W Weight](n int, adj Adjacency[W], srcs []int, dst int, h func(v int) W) (path []int, dist W, ok bool) {
+	s := newSearch(n, adj, h)
+	s.run(srcs, dst)
+	if !s.reached[dst] {
+		return nil, 0, false
+	}
+	return s.Path(dst), s.dist[dst], true
+}
+
+// search is the state of a best-first search, which is Dijkstra's
+// algorithm if heuristic is nil and A* otherwise.
+type search[W Weight] struct {
+	ShortestPaths[W]
+	adj       Adjacency[W]
+	heuristic func(v int) W
+	prio      []W               // prio[v] is the priority of v in queue
+	index     []int             // index[v] is the index of v in queue, or -1
+	queue     heap.PosFunc[int] // vertices ordered by prio
+}
+
+func newSearch[W Weight](n int, adj Adjacency[W], heuristic func(v int) W) *search[W] {
+	s := &search[W]{
+		ShortestPaths: ShortestPaths[W]{
+			dist:    make([]W, n),
+			pred:    make([]int, n),
+			reached: make([]bool, n),
+		},
+		adj:       adj,
+		heuristic: heuristic,
+		prio:      make([]W, n),
+		index:     make([]int, n),
+	}
+	s.queue.Cmp = func(u, v int) int { return cmp.Compare(s.prio[u], s.prio[v]) }
+	s.queue.SetPos = func(v, i int) { s.index[v] = i }
+	for v := range s.pred {
+		s.pred[v] = -1
+		s.index[v] = -1
+	}
+	return s
+}
+
+func (s *search[W]) priority(v int) W {
+	if s.heuristic == nil {
+		return s.dist[v]
+	}
+	return s.dist[v] + s.heuristic(v)
+}
+
+// run searches from the sources until the queue is empty or
+// the destination dst is closed. dst is -1 for no destination.
+func (s *search[W]) run(srcs []int, dst int) {
+	for _, src := range srcs {
+		if s.reached[src] {
+			continue
+		}
+		s.reached[src] = true
+		s.prio[src] = s.priority(src)
+		s.queue.Push(src)
+	}
+	for len(s.queue.Values) > 0 {
+		u := s.queue.Pop()
+		if u == dst {
+			return
+		}
+		s.adj(u, func(v int, w W) {
+			if w < 0 {
+				panic("graph: negative edge weight")
+			}
+			d := s.dist[u] + w
+			if s.reached[v] && d >= s.dist[v] {
+				return
+			}
+			s.dist[v], s.pred[v], s.reached[v] = d, u, true
+			s.prio[v] = s.priority(v)
+			if i := s.index[v]; i >= 0 {
+				s.queue.Fix(i)
+			} else {
+				// v is pushed again if it was popped with a longer distance,
+				// which happens only with an inconsistent heuristic.
+				s.queue.Push(v)
+			}
+		})
+	}
+}
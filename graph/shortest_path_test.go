@@ -0,0 +1,163 @@
+package graph
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+type edge[W Weight] struct {
+	from, to int
+	w        W
+}
+
+// adjacency returns an Adjacency of the directed graph with the edges.
+func adjacency[W Weight](n int, edges []edge[W]) Adjacency[W] {
+	out := make([][]edge[W], n)
+	for _, e := range edges {
+		out[e.from] = append(out[e.from], e)
+	}
+	return func(u int, yield func(v int, w W)) {
+		for _, e := range out[u] {
+			yield(e.to, e.w)
+		}
+	}
+}
+
+// undirected returns the edges in both directions.
+func undirected[W Weight](edges []edge[W]) []edge[W] {
+	var ret []edge[W]
+	for _, e := range edges {
+		ret = append(ret, e, edge[W]{from: e.to, to: e.from, w: e.w})
+	}
+	return ret
+}
+
+// clrsGraph is the graph in Figure 24.6 of Introduction to Algorithms
+// with vertices s, t, x, y, z numbered from 0 to 4.
+var clrsGraph = []edge[int64]{
+	{0, 1, 10}, {0, 3, 5},
+	{1, 2, 1}, {1, 3, 2},
+	{2, 4, 4},
+	{3, 1, 3}, {3, 2, 9}, {3, 4, 2},
+	{4, 0, 7}, {4, 2, 6},
+}
+
+func TestDijkstra(t *testing.T) {
+	// 5 is not reachable.
+	p := Dijkstra(6, adjacency(6, clrsGraph), 0)
+
+	wantDist := []int64{0, 8, 9, 5, 7}
+	wantPaths := [][]int{{0}, {0, 3, 1}, {0, 3, 1, 2}, {0, 3}, {0, 3, 4}}
+	for v := range wantDist {
+		if d, ok := p.Dist(v); !ok || d != wantDist[v] {
+			t.Errorf("Dist(%d) = %d, %v; want %d, true", v, d, ok, wantDist[v])
+		}
+		if path := p.Path(v); !slices.Equal(path, wantPaths[v]) {
+			t.Errorf("Path(%d) = %v; want %v", v, path, wantPaths[v])
+		}
+	}
+	if p.Pred(0) != -1 {
+		t.Errorf("Pred(0) = %d; want -1", p.Pred(0))
+	}
+	if _, ok := p.Dist(5); ok {
+		t.Errorf("Dist(5) is reachable")
+	}
+	if path := p.Path(5); path != nil {
+		t.Errorf("Path(5) = %v; want nil", path)
+	}
+}
+
+func TestMultiSourceDijkstra(t *testing.T) {
+	// A path graph 0 - 1 - 2 - 3 - 4 - 5 - 6 with sources 0 and 6.
+	var edges []edge[float64]
+	for v := 0; v < 6; v++ {
+		edges = append(edges, edge[float64]{v, v + 1, 1.5})
+	}
+	p := MultiSourceDijkstra(7, adjacency(7, undirected(edges)), []int{0, 6})
+
+	wantDist := []float64{0, 1.5, 3, 4.5, 3, 1.5, 0}
+	for v, want := range wantDist {
+		if d, ok := p.Dist(v); !ok || d != want {
+			t.Errorf("Dist(%d) = %g, %v; want %g, true", v, d, ok, want)
+		}
+	}
+	if path := p.Path(4); !slices.Equal(path, []int{6, 5, 4}) {
+		t.Errorf("Path(4) = %v; want [6 5 4]", path)
+	}
+}
+
+func TestDijkstraNegativeWeight(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Dijkstra with a negative weight did not panic")
+		}
+	}()
+	Dijkstra(2, adjacency(2, []edge[int64]{{0, 1, -1}}), 0)
+}
+
+// grid returns the adjacency of a w x h grid with walls, where the vertex
+// of (x, y) is y*w + x and the weight of each move is 1.
+func grid(w, h int, walls map[int]bool) Adjacency[float64] {
+	return func(u int, yield func(v int, d float64)) {
+		x, y := u%w, u/w
+		for _, d := range [][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			nx, ny := x+d[0], y+d[1]
+			if nx < 0 || nx >= w || ny < 0 || ny >= h || walls[ny*w+nx] {
+				continue
+			}
+			yield(ny*w+nx, 1)
+		}
+	}
+}
+
+func TestAStar(t *testing.T) {
+	// S . . . .
+	// # # # # .
+	// . . . . .
+	// . # # # #
+	// . . . . D
+	const w, h = 5, 5
+	walls := map[int]bool{5: true, 6: true, 7: true, 8: true, 16: true, 17: true, 18: true, 19: true}
+	src, dst := 0, 24
+	manhattan := func(v int) float64 {
+		return math.Abs(float64(v%w-dst%w)) + math.Abs(float64(v/w-dst/w))
+	}
+
+	expanded := 0
+	adj := grid(w, h, walls)
+	counting := func(u int, yield func(v int, d float64)) {
+		expanded++
+		adj(u, yield)
+	}
+
+	path, dist, ok := AStar(w*h, counting, src, dst, manhattan)
+	if !ok || dist != 16 {
+		t.Fatalf("AStar = %v, %g, %v; want a path of length 16", path, dist, ok)
+	}
+	want := []int{0, 1, 2, 3, 4, 9, 14, 13, 12, 11, 10, 15, 20, 21, 22, 23, 24}
+	if !slices.Equal(path, want) {
+		t.Errorf("path = %v; want %v", path, want)
+	}
+	if expanded >= w*h-len(walls) {
+		t.Errorf("AStar expanded %d vertices; want fewer than all", expanded)
+	}
+
+	walls[23] = true
+	if path, _, ok := AStar(w*h, grid(w, h, walls), src, dst, manhattan); ok {
+		t.Errorf("AStar found path %v to a walled-in destination", path)
+	}
+}
+
+func TestMultiSourceAStar(t *testing.T) {
+	// A path graph 0 - 1 - ... - 9 with sources 0 and 7.
+	var edges []edge[int64]
+	for v := 0; v < 9; v++ {
+		edges = append(edges, edge[int64]{v, v + 1, 2})
+	}
+	zero := func(v int) int64 { return 0 }
+	path, dist, ok := MultiSourceAStar(10, adjacency(10, undirected(edges)), []int{0, 7}, 9, zero)
+	if !ok || dist != 4 || !slices.Equal(path, []int{7, 8, 9}) {
+		t.Errorf("MultiSourceAStar = %v, %d, %v; want [7 8 9], 4, true", path, dist, ok)
+	}
+}
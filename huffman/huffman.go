@@ -0,0 +1,292 @@
+// Package huffman builds canonical Huffman codes from symbol frequencies
+// and encodes and decodes symbols with them.
+//
+// Symbols are integers in [0, n) where n is the number of frequencies.
+// Codes are packed into bytes from the most significant bit.
+package huffman
+
+import (
+	"cmp"
+	"errors"
+	"sort"
+
+	"github.com/hnakamur/heap"
+)
+
+// MaxCodeLen is the maximum length of codes in bits.
+const MaxCodeLen = 32
+
+// Code is a Huffman code of a symbol. The code is the Len least
+// significant bits of Bits, which are written from the most significant one.
+type Code struct {
+	Bits uint32
+	Len  uint8
+}
+
+var (
+	// ErrTooManySymbols is returned by CodeLengths if the symbols cannot
+	// be coded in the maximum code length.
+	ErrTooManySymbols = errors.New("huffman: too many symbols for maximum code length")
+
+	// ErrNoCode is returned by Encode if a symbol has no code.
+	ErrNoCode = errors.New("huffman: symbol has no code")
+
+	// ErrInvalidCode is returned by CanonicalCodes and Decode if the code
+	// lengths are longer than MaxCodeLen or violate the Kraft inequality,
+	// and by Decode if the data has an invalid code or ends in the middle
+	// of the symbols.
+	ErrInvalidCode = errors.New("huffman: invalid code")
+)
+
+// node is a tree node in the frequency heap. Leaves are the symbols and
+// internal nodes are numbered after them.
+type node struct {
+	freq uint64
+	id   int
+}
+
+func compareNode(a, b node) int {
+	if c := cmp.Compare(a.freq, b.freq); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.id, b.id)
+}
+
+// CodeLengths returns the lengths of Huffman codes for the symbols with the
+// frequencies. Symbols with zero frequencies have zero lengths, which means
+// they have no codes. If there is only one symbol with a non-zero frequency,
+// its length is 1.
+//
+// If maxLen is positive, the lengths are limited to maxLen by lengthening
+// the codes of the least frequent symbols, which keeps the code near optimal
+// but not necessarily optimal. If maxLen is zero or greater than MaxCodeLen,
+// MaxCodeLen is used.
+func CodeLengths(freqs []uint64, maxLen int) ([]uint8, error) {
+	if maxLen <= 0 || maxLen > MaxCodeLen {
+		maxLen = MaxCodeLen
+	}
+	n := len(freqs)
+	lengths := make([]uint8, n)
+
+	h := &heap.Func[node]{Cmp: compareNode}
+	for sym, f := range freqs {
+		if f > 0 {
+			h.Values = append(h.Values, node{freq: f, id: sym})
+		}
+	}
+	used := len(h.Values)
+	switch {
+	case used == 0:
+		return lengths, nil
+	case used == 1:
+		lengths[h.Values[0].id] = 1
+		return lengths, nil
+	case uint64(used) > uint64(1)<<maxLen:
+		return nil, ErrTooManySymbols
+	}
+	h.Init()
+
+	// Build the tree by merging the two least frequent nodes.
+	parent := make([]int, n+used-1)
+	next := n
+	for len(h.Values) > 1 {
+		a, b := h.Pop(), h.Pop()
+		parent[a.id], parent[b.id] = next, next
+		h.Push(node{freq: a.freq + b.freq, id: next})
+		next++
+	}
+	root := next - 1
+
+	// Internal nodes are created after their children, so the depths can be
+	// computed from the root down.
+	depth := make([]int, len(parent))
+	for id := root - 1; id >= n; id-- {
+		depth[id] = depth[parent[id]] + 1
+	}
+	tooLong := false
+	for sym, f := range freqs {
+		if f > 0 {
+			d := depth[parent[sym]] + 1
+			if d > maxLen {
+				d, tooLong = maxLen, true
+			}
+			lengths[sym] = uint8(d)
+		}
+	}
+	if tooLong {
+		limitLengths(freqs, lengths, maxLen)
+	}
+	return lengths, nil
+}
+
+// limitLengths adjusts the lengths, some of which have been clamped to
+// maxLen, so that they satisfy the Kraft inequality again.
+func limitLengths(freqs []uint64, lengths []uint8, maxLen int) {
+	var syms []int
+	for sym, l := range lengths {
+		if l > 0 {
+			syms = append(syms, sym)
+		}
+	}
+	// Sort the symbols from the least frequent one.
+	sort.SliceStable(syms, func(i, j int) bool {
+		return freqs[syms[i]] < freqs[syms[j]]
+	})
+
+	// kraft is the Kraft sum scaled by 2^maxLen.
+	limit := uint64(1) << maxLen
+	var kraft uint64
+	for _, sym := range syms {
+		kraft += uint64(1) << (maxLen - int(lengths[sym]))
+	}
+
+	// Lengthen the codes of the least frequent symbols until the codes fit.
+	for kraft > limit {
+		for _, sym := range syms {
+			if int(lengths[sym]) < maxLen {
+				lengths[sym]++
+				kraft -= uint64(1) << (maxLen - int(lengths[sym]))
+				if kraft <= limit {
+					break
+				}
+			}
+		}
+	}
+
+	// Shorten the codes of the most frequent symbols with the remaining slack.
+	for i := len(syms) - 1; i >= 0; i-- {
+		sym := syms[i]
+		for lengths[sym] > 1 {
+			gain := uint64(1) << (maxLen - int(lengths[sym]))
+			if kraft+gain > limit {
+				break
+			}
+			lengths[sym]--
+			kraft += gain
+		}
+	}
+}
+
+// CanonicalCodes returns the canonical Huffman codes for the code lengths.
+// Shorter codes precede longer ones, and codes of the same length are
+// assigned in the order of the symbols, as in DEFLATE.
+// It returns ErrInvalidCode if no prefix code has the code lengths.
+func CanonicalCodes(lengths []uint8) ([]Code, error) {
+	count, ok := countLengths(lengths)
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+
+	var nextCode [MaxCodeLen + 2]uint32
+	for l := 1; l <= MaxCodeLen; l++ {
+		nextCode[l+1] = (nextCode[l] + uint32(count[l])) << 1
+	}
+
+	codes := make([]Code, len(lengths))
+	for sym, l := range lengths {
+		if l > 0 {
+			codes[sym] = Code{Bits: nextCode[l], Len: l}
+			nextCode[l]++
+		}
+	}
+	return codes, nil
+}
+
+// countLengths returns the number of codes of each length, where count[0]
+// is zero. ok is false if a length is longer than MaxCodeLen, or if the
+// lengths are over-subscribed, that is the sum of 2^-l for the lengths l
+// exceeds 1, since no prefix code has such lengths.
+func countLengths(lengths []uint8) (count [MaxCodeLen + 1]int, ok bool) {
+	var kraft uint64 // sum of 2^(MaxCodeLen-l)
+	for _, l := range lengths {
+		if l > MaxCodeLen {
+			return count, false
+		}
+		if l > 0 {
+			count[l]++
+			kraft += 1 << (MaxCodeLen - l)
+		}
+	}
+	return count, kraft <= 1<<MaxCodeLen
+}
+
+// Encode encodes the symbols with the codes. The last byte is padded with
+// zero bits. It returns ErrNoCode if a symbol has no code.
+func Encode(symbols []int, codes []Code) ([]byte, error) {
+	var (
+		dst   []byte
+		acc   uint64
+		nbits uint
+	)
+	for _, sym := range symbols {
+		if sym < 0 || sym >= len(codes) || codes[sym].Len == 0 {
+			return nil, ErrNoCode
+		}
+		c := codes[sym]
+		acc = acc<<c.Len | uint64(c.Bits)
+		nbits += uint(c.Len)
+		for nbits >= 8 {
+			nbits -= 8
+			dst = append(dst, byte(acc>>nbits))
+		}
+	}
+	if nbits > 0 {
+		dst = append(dst, byte(acc<<(8-nbits)))
+	}
+	return dst, nil
+}
+
+// Decode decodes n symbols from the data encoded with the canonical codes
+// for the code lengths. It returns ErrInvalidCode if no prefix code has
+// the code lengths, if n is negative, or if the data has an invalid code
+// or is too short. The code lengths and n may come from untrusted input.
+func Decode(data []byte, lengths []uint8, n int) ([]int, error) {
+	count, ok := countLengths(lengths)
+	if !ok || n < 0 {
+		return nil, ErrInvalidCode
+	}
+
+	// sorted is the symbols in the order of their canonical codes.
+	offsets := make([]int, MaxCodeLen+2)
+	for l := 1; l <= MaxCodeLen; l++ {
+		offsets[l+1] = offsets[l] + count[l]
+	}
+	sorted := make([]int, offsets[MaxCodeLen+1])
+	for sym, l := range lengths {
+		if l > 0 {
+			sorted[offsets[l]] = sym
+			offsets[l]++
+		}
+	}
+
+	// Each code is at least one bit long, so data has at most len(data)*8
+	// symbols whatever n is.
+	symbols := make([]int, 0, min(n, len(data)*8))
+	pos := 0 // bit position in data
+	for len(symbols) < n {
+		// Read bits one by one and compare the code with the first code
+		// of each length, as in puff.c of zlib.
+		code, first, index := 0, 0, 0
+		found := false
+		for l := 1; l <= MaxCodeLen; l++ {
+			if pos >= len(data)*8 {
+				return nil, ErrInvalidCode
+			}
+			bit := int(data[pos/8]>>(7-pos%8)) & 1
+			pos++
+			code |= bit
+			if code-first < count[l] {
+				symbols = append(symbols, sorted[index+code-first])
+				found = true
+				break
+			}
+			index += count[l]
+			first = (first + count[l]) << 1
+			code <<= 1
+		}
+		if !found {
+			return nil, ErrInvalidCode
+		}
+	}
+	return symbols, nil
+}
@@ -0,0 +1,207 @@
+package huffman
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// kraftSum returns the Kraft sum of the lengths scaled by 2^MaxCodeLen.
+func kraftSum(lengths []uint8) uint64 {
+	var sum uint64
+	for _, l := range lengths {
+		if l > 0 {
+			sum += uint64(1) << (MaxCodeLen - int(l))
+		}
+	}
+	return sum
+}
+
+func cost(freqs []uint64, lengths []uint8) uint64 {
+	var c uint64
+	for sym, f := range freqs {
+		c += f * uint64(lengths[sym])
+	}
+	return c
+}
+
+func TestCodeLengths(t *testing.T) {
+	// The example in Figure 16.3 of Introduction to Algorithms.
+	freqs := []uint64{45, 13, 12, 16, 9, 5}
+	lengths, err := CodeLengths(freqs, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint8{1, 3, 3, 3, 4, 4}; !slices.Equal(lengths, want) {
+		t.Errorf("lengths = %v; want %v", lengths, want)
+	}
+	if got := cost(freqs, lengths); got != 224 {
+		t.Errorf("cost = %d; want 224", got)
+	}
+}
+
+func TestCodeLengthsSpecial(t *testing.T) {
+	testCases := []struct {
+		name  string
+		freqs []uint64
+		want  []uint8
+	}{
+		{name: "Empty", freqs: nil, want: []uint8{}},
+		{name: "AllZero", freqs: []uint64{0, 0}, want: []uint8{0, 0}},
+		{name: "OneSymbol", freqs: []uint64{0, 7, 0}, want: []uint8{0, 1, 0}},
+		{name: "TwoSymbols", freqs: []uint64{3, 0, 1}, want: []uint8{1, 0, 1}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := CodeLengths(tc.freqs, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Errorf("lengths = %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCodeLengthsLimited(t *testing.T) {
+	// Fibonacci frequencies make the deepest possible tree.
+	freqs := make([]uint64, 20)
+	freqs[0], freqs[1] = 1, 1
+	for i := 2; i < len(freqs); i++ {
+		freqs[i] = freqs[i-1] + freqs[i-2]
+	}
+	unlimited, err := CodeLengths(freqs, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := slices.Max(unlimited); got != 19 {
+		t.Fatalf("max length = %d; want 19", got)
+	}
+
+	for maxLen := 5; maxLen <= 19; maxLen++ {
+		lengths, err := CodeLengths(freqs, maxLen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Max(lengths); int(got) > maxLen {
+			t.Errorf("maxLen %d: max length = %d", maxLen, got)
+		}
+		if got := kraftSum(lengths); got > 1<<MaxCodeLen {
+			t.Errorf("maxLen %d: Kraft sum exceeds 1", maxLen)
+		}
+		if cost(freqs, lengths) < cost(freqs, unlimited) {
+			t.Errorf("maxLen %d: cost is less than the optimal cost", maxLen)
+		}
+	}
+
+	if _, err := CodeLengths(freqs, 4); err != ErrTooManySymbols {
+		t.Errorf("CodeLengths with maxLen 4 returned %v; want %v", err, ErrTooManySymbols)
+	}
+}
+
+func TestCanonicalCodes(t *testing.T) {
+	// The example in section 3.2.2 of RFC 1951.
+	lengths := []uint8{3, 3, 3, 3, 3, 2, 4, 4}
+	want := []Code{
+		{2, 3}, {3, 3}, {4, 3}, {5, 3}, {6, 3}, {0, 2}, {14, 4}, {15, 4},
+	}
+	got, err := CanonicalCodes(lengths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("codes = %v; want %v", got, want)
+	}
+}
+
+func TestDecodeInvalidCount(t *testing.T) {
+	lengths := []uint8{1, 1}
+	if _, err := Decode([]byte{0}, lengths, -1); err != ErrInvalidCode {
+		t.Errorf("Decode with n = -1 returned error %v; want %v", err, ErrInvalidCode)
+	}
+	// A huge n must fail for the short data instead of allocating for n symbols.
+	if _, err := Decode([]byte{0}, lengths, math.MaxInt); err != ErrInvalidCode {
+		t.Errorf("Decode with n = MaxInt returned error %v; want %v", err, ErrInvalidCode)
+	}
+	if got, err := Decode([]byte{0b0101_0000}, lengths, 4); err != nil || !slices.Equal(got, []int{0, 1, 0, 1}) {
+		t.Errorf("Decode = %v, %v; want [0 1 0 1], nil", got, err)
+	}
+}
+
+func TestInvalidLengths(t *testing.T) {
+	for _, lengths := range [][]uint8{
+		{40, 1},                // longer than MaxCodeLen
+		{MaxCodeLen + 1},       // just longer than MaxCodeLen
+		{1, 1, 1},              // over-subscribed
+		{1, 2, 2, 3},           // over-subscribed
+		{2, 2, 2, 2, 2},        // over-subscribed
+		{255, 255, 255, 1, 40}, // both
+	} {
+		if _, err := CanonicalCodes(lengths); err != ErrInvalidCode {
+			t.Errorf("CanonicalCodes(%v) returned error %v; want %v", lengths, err, ErrInvalidCode)
+		}
+		if _, err := Decode([]byte{0}, lengths, 1); err != ErrInvalidCode {
+			t.Errorf("Decode(%v) returned error %v; want %v", lengths, err, ErrInvalidCode)
+		}
+	}
+
+	// Incomplete codes are valid as long as the data has no unused code.
+	lengths := []uint8{1, 0, 2}
+	if _, err := CanonicalCodes(lengths); err != nil {
+		t.Errorf("CanonicalCodes(%v) returned error %v; want nil", lengths, err)
+	}
+	if got, err := Decode([]byte{0b10_0_10_000}, lengths, 3); err != nil || !slices.Equal(got, []int{2, 0, 2}) {
+		t.Errorf("Decode(%v) = %v, %v; want [2 0 2], nil", lengths, got, err)
+	}
+	if _, err := Decode([]byte{0b11_000000}, lengths, 1); err != ErrInvalidCode {
+		t.Errorf("Decode of an unused code returned error %v; want %v", err, ErrInvalidCode)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, maxLen := range []int{0, 8, 12} {
+		// Zipf-like frequencies over 256 symbols, some of which are unused.
+		freqs := make([]uint64, 256)
+		for sym := range freqs {
+			if sym%17 != 3 {
+				freqs[sym] = uint64(100000 / (sym + 1))
+			}
+		}
+		lengths, err := CodeLengths(freqs, maxLen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		codes, err := CanonicalCodes(lengths)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var symbols []int
+		for len(symbols) < 5000 {
+			if sym := rnd.Intn(256); freqs[sym] > 0 {
+				symbols = append(symbols, sym)
+			}
+		}
+		data, err := Encode(symbols, codes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := Decode(data, lengths, len(symbols))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, symbols) {
+			t.Errorf("maxLen %d: decoded symbols differ", maxLen)
+		}
+
+		if _, err := Decode(data[:len(data)/2], lengths, len(symbols)); err != ErrInvalidCode {
+			t.Errorf("maxLen %d: Decode of truncated data returned %v; want %v", maxLen, err, ErrInvalidCode)
+		}
+		if _, err := Encode([]int{3}, codes); err != ErrNoCode {
+			t.Errorf("maxLen %d: Encode of unused symbol returned %v; want %v", maxLen, err, ErrNoCode)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package heap
+
+// IndexedFunc is a heap for getting the minimum value of type T ordered by Cmp.
+// Unlike Func, it keeps the index of each value in the heap, so that a value
+// can be updated or deleted by the value itself instead of its index.
+// Values in the heap are distinct; pushing a value which is already in the heap
+// is rejected.
+//
+// A heap of strings like MinStr can be created with NewIndexedFunc(strings.Compare).
+type IndexedFunc[T comparable] struct {
+	values []T
+	index  map[T]int
+	cmp    func(a, b T) int
+}
+
+// NewIndexedFunc returns an empty heap ordered by cmp.
+func NewIndexedFunc[T comparable](cmp func(a, b T) int) *IndexedFunc[T] {
+	return &IndexedFunc[T]{index: make(map[T]int), cmp: cmp}
+}
+
+// Len returns the number of values in the heap.
+func (h *IndexedFunc[T]) Len() int { return len(h.values) }
+
+// Min returns the minimum value in the heap without removing it.
+// Min panics if the heap is empty.
+func (h *IndexedFunc[T]) Min() T { return h.values[0] }
+
+// Contains reports whether x is in the heap.
+// The complexity is O(1).
+func (h *IndexedFunc[T]) Contains(x T) bool {
+	_, ok := h.index[x]
+	return ok
+}
+
+// Push pushes the value x onto the heap and reports whether it is pushed.
+// It returns false without modifying the heap if x is already in the heap.
+// The complexity is O(log n) where n = h.Len().
+func (h *IndexedFunc[T]) Push(x T) bool {
+	if _, ok := h.index[x]; ok {
+		return false
+	}
+	h.push(x)
+	h.up(h.length() - 1)
+	return true
+}
+
+// Pop removes and returns the minimum value from the heap.
+// The complexity is O(log n) where n = h.Len().
+func (h *IndexedFunc[T]) Pop() T {
+	n := h.length() - 1
+	h.swap(0, n)
+	h.down(0, n)
+	return h.pop()
+}
+
+// Delete removes the value x from the heap and reports whether it was in the heap.
+// The complexity is O(log n) where n = h.Len().
+func (h *IndexedFunc[T]) Delete(x T) bool {
+	i, ok := h.index[x]
+	if !ok {
+		return false
+	}
+	n := h.length() - 1
+	if n != i {
+		h.swap(i, n)
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+	h.pop()
+	return true
+}
+
+// Update replaces the value old in the heap with new and re-establishes
+// the heap ordering. It returns false without modifying the heap if old is
+// not in the heap, or if new is already in the heap and differs from old.
+// The complexity is O(log n) where n = h.Len().
+func (h *IndexedFunc[T]) Update(old, new T) bool {
+	i, ok := h.index[old]
+	if !ok {
+		return false
+	}
+	if old != new {
+		if _, ok := h.index[new]; ok {
+			return false
+		}
+		delete(h.index, old)
+		h.values[i] = new
+		h.index[new] = i
+	}
+	if !h.down(i, h.length()) {
+		h.up(i)
+	}
+	return true
+}
+
+func (h *IndexedFunc[T]) up(j int) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		j = i
+	}
+}
+
+func (h *IndexedFunc[T]) down(i0, n int) bool {
+	i := i0
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && h.less(j2, j1) {
+			j = j2 // = 2*i + 2  // right child
+		}
+		if !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		i = j
+	}
+	return i > i0
+}
+
+func (h *IndexedFunc[T]) length() int        { return len(h.values) }
+func (h *IndexedFunc[T]) less(i, j int) bool { return h.cmp(h.values[i], h.values[j]) < 0 }
+
+func (h *IndexedFunc[T]) swap(i, j int) {
+	h.values[i], h.values[j] = h.values[j], h.values[i]
+	h.index[h.values[i]] = i
+	h.index[h.values[j]] = j
+}
+
+func (h *IndexedFunc[T]) push(x T) {
+	h.index[x] = len(h.values)
+	h.values = append(h.values, x)
+}
+
+func (h *IndexedFunc[T]) pop() (x T) {
+	h.values, x = h.values[:h.length()-1], h.values[h.length()-1]
+	delete(h.index, x)
+	return
+}
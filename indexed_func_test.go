@@ -0,0 +1,200 @@
+package heap
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func (h *IndexedFunc[T]) verify(t *testing.T, i int) {
+	t.Helper()
+	n := h.length()
+	if i == 0 {
+		if len(h.index) != n {
+			t.Errorf("index has %d entries; want %d", len(h.index), n)
+		}
+		for j, x := range h.values {
+			if k, ok := h.index[x]; !ok || k != j {
+				t.Errorf("index[%v] = %d, %v; want %d, true", x, k, ok, j)
+			}
+		}
+	}
+	j1 := 2*i + 1
+	j2 := 2*i + 2
+	if j1 < n {
+		if h.less(j1, i) {
+			t.Errorf("heap invariant invalidated [%d] = %v > [%d] = %v", i, h.values[i], j1, h.values[j1])
+			return
+		}
+		h.verify(t, j1)
+	}
+	if j2 < n {
+		if h.less(j2, i) {
+			t.Errorf("heap invariant invalidated [%d] = %v > [%d] = %v", i, h.values[i], j1, h.values[j2])
+			return
+		}
+		h.verify(t, j2)
+	}
+}
+
+func TestIndexedFunc(t *testing.T) {
+	h := NewIndexedFunc(strings.Compare)
+	h.verify(t, 0)
+
+	for i := 20; i > 0; i-- {
+		if !h.Push(toHex(uint64(i))) {
+			t.Errorf("Push(%q) = false; want true", toHex(uint64(i)))
+		}
+		h.verify(t, 0)
+	}
+	for i := 1; h.Len() > 0; i++ {
+		if !h.Contains(toHex(uint64(i))) {
+			t.Errorf("Contains(%q) = false; want true", toHex(uint64(i)))
+		}
+		if x := h.Min(); x != toHex(uint64(i)) {
+			t.Errorf("%d.th min got %q; want %q", i, x, toHex(uint64(i)))
+		}
+		x := h.Pop()
+		h.verify(t, 0)
+		if x != toHex(uint64(i)) {
+			t.Errorf("%d.th pop got %q; want %q", i, x, toHex(uint64(i)))
+		}
+		if h.Contains(x) {
+			t.Errorf("Contains(%q) = true after Pop; want false", x)
+		}
+	}
+}
+
+func TestIndexedFuncDuplicate(t *testing.T) {
+	h := NewIndexedFunc(strings.Compare)
+	if !h.Push("a") {
+		t.Error("Push(\"a\") = false; want true")
+	}
+	if h.Push("a") {
+		t.Error("second Push(\"a\") = true; want false")
+	}
+	if h.Len() != 1 {
+		t.Errorf("Len() = %d; want 1", h.Len())
+	}
+	h.Push("b")
+	if h.Update("a", "b") {
+		t.Error("Update(\"a\", \"b\") = true; want false")
+	}
+	if !h.Update("a", "a") {
+		t.Error("Update(\"a\", \"a\") = false; want true")
+	}
+	h.verify(t, 0)
+}
+
+func TestIndexedFuncUpdate(t *testing.T) {
+	h := NewIndexedFunc(strings.Compare)
+	for i := 1; i <= 10; i++ {
+		h.Push(toHex(uint64(i * 10)))
+	}
+	h.verify(t, 0)
+
+	if h.Update(toHex(uint64(5)), toHex(uint64(6))) {
+		t.Errorf("Update(%q, ...) = true for a missing value; want false", toHex(uint64(5)))
+	}
+	if !h.Update(toHex(uint64(50)), toHex(uint64(5))) {
+		t.Errorf("Update(%q, %q) = false; want true", toHex(uint64(50)), toHex(uint64(5)))
+	}
+	h.verify(t, 0)
+	if x := h.Min(); x != toHex(uint64(5)) {
+		t.Errorf("Min() = %q; want %q", x, toHex(uint64(5)))
+	}
+	if !h.Update(toHex(uint64(5)), toHex(uint64(200))) {
+		t.Errorf("Update(%q, %q) = false; want true", toHex(uint64(5)), toHex(uint64(200)))
+	}
+	h.verify(t, 0)
+	if h.Contains(toHex(uint64(5))) || h.Contains(toHex(uint64(50))) || !h.Contains(toHex(uint64(200))) {
+		t.Error("Contains does not reflect updates")
+	}
+	if x := h.Min(); x != toHex(uint64(10)) {
+		t.Errorf("Min() = %q; want %q", x, toHex(uint64(10)))
+	}
+}
+
+func TestIndexedFuncDelete(t *testing.T) {
+	h := NewIndexedFunc(strings.Compare)
+	for i := 0; i < 10; i++ {
+		h.Push(toHex(uint64(i)))
+	}
+	h.verify(t, 0)
+
+	if h.Delete(toHex(uint64(10))) {
+		t.Errorf("Delete(%q) = true for a missing value; want false", toHex(uint64(10)))
+	}
+	for i := 0; i < 10; i += 2 {
+		if !h.Delete(toHex(uint64(i))) {
+			t.Errorf("Delete(%q) = false; want true", toHex(uint64(i)))
+		}
+		h.verify(t, 0)
+	}
+	for i := 1; h.Len() > 0; i += 2 {
+		if x := h.Pop(); x != toHex(uint64(i)) {
+			t.Errorf("Pop() = %q; want %q", x, toHex(uint64(i)))
+		}
+	}
+}
+
+func TestIndexedFuncRandom(t *testing.T) {
+	h := NewIndexedFunc(cmp.Compare[int])
+	var oracle []int
+	for i := 0; i < 10000; i++ {
+		x := rand.Intn(200)
+		_, found := slices.BinarySearch(oracle, x)
+		switch rand.Intn(4) {
+		case 0:
+			if got := h.Push(x); got == found {
+				t.Fatalf("Push(%d) = %v; want %v", x, got, !found)
+			}
+			if !found {
+				oracle = insertSorted(oracle, x)
+			}
+		case 1:
+			if got := h.Delete(x); got != found {
+				t.Fatalf("Delete(%d) = %v; want %v", x, got, found)
+			}
+			if found {
+				oracle = deleteSorted(oracle, x)
+			}
+		case 2:
+			y := rand.Intn(200)
+			_, yFound := slices.BinarySearch(oracle, y)
+			want := found && (x == y || !yFound)
+			if got := h.Update(x, y); got != want {
+				t.Fatalf("Update(%d, %d) = %v; want %v", x, y, got, want)
+			}
+			if want {
+				oracle = insertSorted(deleteSorted(oracle, x), y)
+			}
+		case 3:
+			if len(oracle) > 0 {
+				if got := h.Pop(); got != oracle[0] {
+					t.Fatalf("Pop() = %d; want %d", got, oracle[0])
+				}
+				oracle = oracle[1:]
+			}
+		}
+		h.verify(t, 0)
+		if h.Len() != len(oracle) {
+			t.Fatalf("Len() = %d; want %d", h.Len(), len(oracle))
+		}
+		if got := h.Contains(x); got != slices.Contains(oracle, x) {
+			t.Fatalf("Contains(%d) = %v; want %v", x, got, !got)
+		}
+	}
+}
+
+func insertSorted(s []int, x int) []int {
+	i, _ := slices.BinarySearch(s, x)
+	return slices.Insert(s, i, x)
+}
+
+func deleteSorted(s []int, x int) []int {
+	i, _ := slices.BinarySearch(s, x)
+	return slices.Delete(s, i, i+1)
+}
@@ -0,0 +1,234 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package heap
+
+import (
+	"expvar"
+	"sync/atomic"
+)
+
+// binaryHeap is the set of unexported methods shared by the array-backed
+// binary heaps of this package, such as *MinInt64, *MaxStr, and *Func[T].
+type binaryHeap[T any] interface {
+	length() int
+	less(i, j int) bool
+	swap(i, j int)
+	push(x T)
+	pop() T
+
+	// binaryLayout marks the heaps whose children of the element at index i
+	// are at 2*i+1 and 2*i+2, which Instrumented sifts elements of.
+	// BlockedMinInt64 and BlockedMinUint64 have other layouts, and IndexedFunc
+	// rejects duplicates in its own Push, so they do not have this method.
+	binaryLayout()
+}
+
+func (MinStr) binaryLayout()      {}
+func (MaxStr) binaryLayout()      {}
+func (MinBytes) binaryLayout()    {}
+func (MaxBytes) binaryLayout()    {}
+func (MinInt64) binaryLayout()    {}
+func (MaxInt64) binaryLayout()    {}
+func (MinUint64) binaryLayout()   {}
+func (MaxUint64) binaryLayout()   {}
+func (MinFloat64) binaryLayout()  {}
+func (MaxFloat64) binaryLayout()  {}
+func (MinFloat32) binaryLayout()  {}
+func (MaxFloat32) binaryLayout()  {}
+func (*StrFunc) binaryLayout()    {}
+func (*BytesFunc) binaryLayout()  {}
+func (*Func[T]) binaryLayout()    {}
+func (*PosFunc[T]) binaryLayout() {}
+
+// Instrumented is a wrapper of an array-backed binary heap of this package,
+// such as *MinInt64, *MaxStr, *StrFunc, or *Func[T], which counts the operations
+// performed on it. The heaps themselves are not instrumented, so they have no
+// overhead unless they are wrapped.
+//
+// The heap must be modified only through the wrapper while it is instrumented.
+// Stats may be called concurrently with the other methods, but the other methods
+// must not be called concurrently with each other, like the methods of the heaps.
+type Instrumented[T any, H binaryHeap[T]] struct {
+	h H
+
+	pushes      atomic.Uint64
+	pops        atomic.Uint64
+	removes     atomic.Uint64
+	fixes       atomic.Uint64
+	inits       atomic.Uint64
+	comparisons atomic.Uint64
+	swaps       atomic.Uint64
+	len         atomic.Int64
+	maxLen      atomic.Int64
+}
+
+// Stats is a snapshot of the counters of an Instrumented heap.
+type Stats struct {
+	Pushes  uint64 // number of calls to Push
+	Pops    uint64 // number of calls to Pop
+	Removes uint64 // number of calls to Remove
+	Fixes   uint64 // number of calls to Fix
+	Inits   uint64 // number of calls to Init
+
+	// Comparisons and Swaps are the numbers of comparisons and swaps
+	// of elements performed while sifting elements up and down.
+	// Many swaps per operation indicate that elements travel far in the heap.
+	Comparisons uint64
+	Swaps       uint64
+
+	Len    int // current number of elements
+	MaxLen int // high-water number of elements
+}
+
+// Instrument returns a wrapper of h which counts the operations performed on h.
+func Instrument[T any, H binaryHeap[T]](h H) *Instrumented[T, H] {
+	w := &Instrumented[T, H]{h: h}
+	w.updateLen()
+	return w
+}
+
+// Heap returns the wrapped heap. Its elements may be read, for example
+// the minimum element at index 0, but must not be modified.
+func (h *Instrumented[T, H]) Heap() H { return h.h }
+
+// Len returns the number of elements in the heap.
+func (h *Instrumented[T, H]) Len() int { return h.h.length() }
+
+// Init establishes the heap invariants required by the other routines in this package.
+// Init is idempotent with respect to the heap invariants
+// and may be called whenever the heap invariants may have been invalidated.
+// The complexity is O(n) where n = h.Len().
+func (h *Instrumented[T, H]) Init() {
+	h.inits.Add(1)
+	// heapify
+	n := h.h.length()
+	for i := n/2 - 1; i >= 0; i-- {
+		h.down(i, n)
+	}
+	h.updateLen()
+}
+
+// Push pushes the element x onto the heap.
+// The complexity is O(log n) where n = h.Len().
+func (h *Instrumented[T, H]) Push(x T) {
+	h.pushes.Add(1)
+	h.h.push(x)
+	h.up(h.h.length() - 1)
+	h.updateLen()
+}
+
+// Pop removes and returns the minimum element (according to the wrapped heap) from the heap.
+// The complexity is O(log n) where n = h.Len().
+// Pop is equivalent to Remove(h, 0).
+func (h *Instrumented[T, H]) Pop() T {
+	h.pops.Add(1)
+	n := h.h.length() - 1
+	h.h.swap(0, n)
+	h.down(0, n)
+	x := h.h.pop()
+	h.updateLen()
+	return x
+}
+
+// Remove removes and returns the element at index i from the heap.
+// The complexity is O(log n) where n = h.Len().
+func (h *Instrumented[T, H]) Remove(i int) T {
+	h.removes.Add(1)
+	n := h.h.length() - 1
+	if n != i {
+		h.h.swap(i, n)
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+	x := h.h.pop()
+	h.updateLen()
+	return x
+}
+
+// Fix re-establishes the heap ordering after the element at index i has changed its value.
+// The complexity is O(log n) where n = h.Len().
+func (h *Instrumented[T, H]) Fix(i int) {
+	h.fixes.Add(1)
+	if !h.down(i, h.h.length()) {
+		h.up(i)
+	}
+}
+
+// Stats returns a snapshot of the counters.
+func (h *Instrumented[T, H]) Stats() Stats {
+	return Stats{
+		Pushes:      h.pushes.Load(),
+		Pops:        h.pops.Load(),
+		Removes:     h.removes.Load(),
+		Fixes:       h.fixes.Load(),
+		Inits:       h.inits.Load(),
+		Comparisons: h.comparisons.Load(),
+		Swaps:       h.swaps.Load(),
+		Len:         int(h.len.Load()),
+		MaxLen:      int(h.maxLen.Load()),
+	}
+}
+
+// Var returns an expvar.Var whose value is the JSON encoding of the current Stats.
+// It can be published with expvar.Publish.
+func (h *Instrumented[T, H]) Var() expvar.Var {
+	return expvar.Func(func() any { return h.Stats() })
+}
+
+// Publish publishes the Stats of h as an exported variable of the expvar package
+// with the given name. Like expvar.Publish, it panics if the name is already used.
+func (h *Instrumented[T, H]) Publish(name string) {
+	expvar.Publish(name, h.Var())
+}
+
+func (h *Instrumented[T, H]) up(j int) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		j = i
+	}
+}
+
+func (h *Instrumented[T, H]) down(i0, n int) bool {
+	i := i0
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && h.less(j2, j1) {
+			j = j2 // = 2*i + 2  // right child
+		}
+		if !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		i = j
+	}
+	return i > i0
+}
+
+func (h *Instrumented[T, H]) less(i, j int) bool {
+	h.comparisons.Add(1)
+	return h.h.less(i, j)
+}
+
+func (h *Instrumented[T, H]) swap(i, j int) {
+	h.swaps.Add(1)
+	h.h.swap(i, j)
+}
+
+func (h *Instrumented[T, H]) updateLen() {
+	n := int64(h.h.length())
+	h.len.Store(n)
+	if n > h.maxLen.Load() {
+		h.maxLen.Store(n)
+	}
+}
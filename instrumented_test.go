@@ -0,0 +1,148 @@
+package heap
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestInstrumentedMinInt64(t *testing.T) {
+	h := Instrument(new(MinInt64))
+	for i := 20; i > 0; i-- {
+		h.Push(int64(i))
+		h.Heap().verify(t, 0)
+	}
+	for i := 1; h.Len() > 0; i++ {
+		x := h.Pop()
+		h.Heap().verify(t, 0)
+		if x != int64(i) {
+			t.Errorf("%d.th pop got %d; want %d", i, x, i)
+		}
+	}
+
+	s := h.Stats()
+	if s.Pushes != 20 || s.Pops != 20 || s.Removes != 0 || s.Fixes != 0 || s.Inits != 0 {
+		t.Errorf("operation counts = %+v; want 20 pushes and 20 pops", s)
+	}
+	if s.Len != 0 || s.MaxLen != 20 {
+		t.Errorf("Len, MaxLen = %d, %d; want 0, 20", s.Len, s.MaxLen)
+	}
+	if s.Comparisons == 0 || s.Swaps == 0 {
+		t.Errorf("Comparisons, Swaps = %d, %d; want non-zero", s.Comparisons, s.Swaps)
+	}
+}
+
+func TestInstrumentedCounts(t *testing.T) {
+	h := Instrument(new(MinInt64))
+	h.Push(3) // no parent
+	h.Push(2) // compared with and swapped for 3
+	h.Push(1) // compared with and swapped for 2
+	s := h.Stats()
+	if s.Comparisons != 2 || s.Swaps != 2 {
+		t.Errorf("Comparisons, Swaps = %d, %d; want 2, 2", s.Comparisons, s.Swaps)
+	}
+
+	h.Push(4) // compared with 2
+	s = h.Stats()
+	if s.Comparisons != 3 || s.Swaps != 2 {
+		t.Errorf("Comparisons, Swaps = %d, %d; want 3, 2", s.Comparisons, s.Swaps)
+	}
+}
+
+func TestInstrumentedStrFunc(t *testing.T) {
+	sf := &StrFunc{Values: []string{"d", "b", "e", "a", "c"}, Cmp: strings.Compare}
+	h := Instrument(sf)
+	h.Init()
+	sf.verify(t, 0)
+
+	sf.Values[0] = "z"
+	h.Fix(0)
+	sf.verify(t, 0)
+	if x := h.Remove(h.Len() - 1); x == "" {
+		t.Error("Remove returned an empty string")
+	}
+	sf.verify(t, 0)
+
+	s := h.Stats()
+	if s.Inits != 1 || s.Fixes != 1 || s.Removes != 1 {
+		t.Errorf("operation counts = %+v; want 1 init, 1 fix, and 1 remove", s)
+	}
+	if s.Len != 4 || s.MaxLen != 5 {
+		t.Errorf("Len, MaxLen = %d, %d; want 4, 5", s.Len, s.MaxLen)
+	}
+}
+
+// publishCount makes the expvar names unique when tests are run repeatedly
+// with -count, since the names cannot be unpublished.
+var publishCount int
+
+func TestInstrumentedPublish(t *testing.T) {
+	publishCount++
+	name := fmt.Sprintf("heap.TestInstrumentedPublish.%d", publishCount)
+	h := Instrument(new(MaxStr))
+	h.Publish(name)
+	h.Push("a")
+	h.Push("b")
+
+	var s Stats
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &s); err != nil {
+		t.Fatal(err)
+	}
+	if s.Pushes != 2 || s.Len != 2 || s.MaxLen != 2 {
+		t.Errorf("published stats = %+v; want 2 pushes and 2 elements", s)
+	}
+}
+
+func TestInstrumentedConcurrentStats(t *testing.T) {
+	h := Instrument(new(MinUint64))
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				_ = h.Var().String()
+			}
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		h.Push(uint64(i * 7 % 13))
+		if i%3 == 0 {
+			h.Pop()
+		}
+	}
+	close(done)
+	wg.Wait()
+}
+
+func TestInstrumentedHeapTypes(t *testing.T) {
+	// Instrument sifts elements as a binary heap, so it must accept only
+	// heaps with the binary layout and no extra bookkeeping in Push.
+	for _, tc := range []struct {
+		h     any
+		iface reflect.Type
+		want  bool
+	}{
+		{new(MinInt64), reflect.TypeOf((*binaryHeap[int64])(nil)).Elem(), true},
+		{new(MaxStr), reflect.TypeOf((*binaryHeap[string])(nil)).Elem(), true},
+		{new(MinFloat32), reflect.TypeOf((*binaryHeap[float32])(nil)).Elem(), true},
+		{new(BytesFunc), reflect.TypeOf((*binaryHeap[[]byte])(nil)).Elem(), true},
+		{new(Func[int]), reflect.TypeOf((*binaryHeap[int])(nil)).Elem(), true},
+		{new(PosFunc[int]), reflect.TypeOf((*binaryHeap[int])(nil)).Elem(), true},
+		{new(BlockedMinInt64), reflect.TypeOf((*binaryHeap[int64])(nil)).Elem(), false},
+		{new(BlockedMinUint64), reflect.TypeOf((*binaryHeap[uint64])(nil)).Elem(), false},
+		{new(IndexedFunc[int]), reflect.TypeOf((*binaryHeap[int])(nil)).Elem(), false},
+	} {
+		if got := reflect.TypeOf(tc.h).Implements(tc.iface); got != tc.want {
+			t.Errorf("%T satisfies the constraint of Instrument: %v; want %v", tc.h, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package heap
+
+// This file provides adapters of the heap types to heap.Interface of
+// the standard library container/heap and sort.Interface. The adapters
+// share the backing slice with the heaps, so the heaps can be passed to
+// code which accepts those interfaces without copying. Sorting a heap with
+// sort.Sort orders the elements in the order they would be popped, which
+// also satisfies the heap invariants.
+
+// MinStrInterface is an adapter of MinStr to heap.Interface and sort.Interface.
+type MinStrInterface struct{ h *MinStr }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *MinStr) Interface() MinStrInterface { return MinStrInterface{h} }
+
+func (a MinStrInterface) Len() int           { return a.h.length() }
+func (a MinStrInterface) Less(i, j int) bool { return a.h.less(i, j) }
+func (a MinStrInterface) Swap(i, j int)      { a.h.swap(i, j) }
+func (a MinStrInterface) Push(x any)         { a.h.push(x.(string)) }
+func (a MinStrInterface) Pop() any           { return a.h.pop() }
+
+// MaxStrInterface is an adapter of MaxStr to heap.Interface and sort.Interface.
+type MaxStrInterface struct{ h *MaxStr }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *MaxStr) Interface() MaxStrInterface { return MaxStrInterface{h} }
+
+func (a MaxStrInterface) Len() int           { return a.h.length() }
+func (a MaxStrInterface) Less(i, j int) bool { return a.h.less(i, j) }
+func (a MaxStrInterface) Swap(i, j int)      { a.h.swap(i, j) }
+func (a MaxStrInterface) Push(x any)         { a.h.push(x.(string)) }
+func (a MaxStrInterface) Pop() any           { return a.h.pop() }
+
+// MinBytesInterface is an adapter of MinBytes to heap.Interface and sort.Interface.
+type MinBytesInterface struct{ h *MinBytes }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *MinBytes) Interface() MinBytesInterface { return MinBytesInterface{h} }
+
+func (a MinBytesInterface) Len() int           { return a.h.length() }
+func (a MinBytesInterface) Less(i, j int) bool { return a.h.less(i, j) }
+func (a MinBytesInterface) Swap(i, j int)      { a.h.swap(i, j) }
+func (a MinBytesInterface) Push(x any)         { a.h.push(x.([]byte)) }
+func (a MinBytesInterface) Pop() any           { return a.h.pop() }
+
+// MaxBytesInterface is an adapter of MaxBytes to heap.Interface and sort.Interface.
+type MaxBytesInterface struct{ h *MaxBytes }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *MaxBytes) Interface() MaxBytesInterface { return MaxBytesInterface{h} }
+
+func (a MaxBytesInterface) Len() int           { return a.h.length() }
+func (a MaxBytesInterface) Less(i, j int) bool { return a.h.less(i, j) }
+func (a MaxBytesInterface) Swap(i, j int)      { a.h.swap(i, j) }
+func (a MaxBytesInterface) Push(x any)         { a.h.push(x.([]byte)) }
+func (a MaxBytesInterface) Pop() any           { return a.h.pop() }
+
+// MinInt64Interface is an adapter of MinInt64 to heap.Interface and sort.Interface.
+type MinInt64Interface struct{ h *MinInt64 }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *MinInt64) Interface() MinInt64Interface { return MinInt64Interface{h} }
+
+func (a MinInt64Interface) Len() int           { return a.h.length() }
+func (a MinInt64Interface) Less(i, j int) bool { return a.h.less(i, j) }
+func (a MinInt64Interface) Swap(i, j int)      { a.h.swap(i, j) }
+func (a MinInt64Interface) Push(x any)         { a.h.push(x.(int64)) }
+func (a MinInt64Interface) Pop() any           { return a.h.pop() }
+
+// MaxInt64Interface is an adapter of MaxInt64 to heap.Interface and sort.Interface.
+type MaxInt64Interface struct{ h *MaxInt64 }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *MaxInt64) Interface() MaxInt64Interface { return MaxInt64Interface{h} }
+
+func (a MaxInt64Interface) Len() int           { return a.h.length() }
+func (a MaxInt64Interface) Less(i, j int) bool { return a.h.less(i, j) }
+func (a MaxInt64Interface) Swap(i, j int)      { a.h.swap(i, j) }
+func (a MaxInt64Interface) Push(x any)         { a.h.push(x.(int64)) }
+func (a MaxInt64Interface) Pop() any           { return a.h.pop() }
+
+// MinUint64Interface is an adapter of MinUint64 to heap.Interface and sort.Interface.
+type MinUint64Interface struct{ h *MinUint64 }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *MinUint64) Interface() MinUint64Interface { return MinUint64Interface{h} }
+
+func (a MinUint64Interface) Len() int           { return a.h.length() }
+func (a MinUint64Interface) Less(i, j int) bool { return a.h.less(i, j) }
+func (a MinUint64Interface) Swap(i, j int)      { a.h.swap(i, j) }
+func (a MinUint64Interface) Push(x any)         { a.h.push(x.(uint64)) }
+func (a MinUint64Interface) Pop() any           { return a.h.pop() }
+
+// MaxUint64Interface is an adapter of MaxUint64 to heap.Interface and sort.Interface.
+type MaxUint64Interface struct{ h *MaxUint64 }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *MaxUint64) Interface() MaxUint64Interface { return MaxUint64Interface{h} }
+
+func (a MaxUint64Interface) Len() int           { return a.h.length() }
+func (a MaxUint64Interface) Less(i, j int) bool { return a.h.less(i, j) }
+func (a MaxUint64Interface) Swap(i, j int)      { a.h.swap(i, j) }
+func (a MaxUint64Interface) Push(x any)         { a.h.push(x.(uint64)) }
+func (a MaxUint64Interface) Pop() any           { return a.h.pop() }
+
+// MinFloat64Interface is an adapter of MinFloat64 to heap.Interface and sort.Interface.
+type MinFloat64Interface struct{ h *MinFloat64 }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *MinFloat64) Interface() MinFloat64Interface { return MinFloat64Interface{h} }
+
+func (a MinFloat64Interface) Len() int           { return a.h.length() }
+func (a MinFloat64Interface) Less(i, j int) bool { return a.h.less(i, j) }
+func (a MinFloat64Interface) Swap(i, j int)      { a.h.swap(i, j) }
+func (a MinFloat64Interface) Push(x any)         { a.h.push(x.(float64)) }
+func (a MinFloat64Interface) Pop() any           { return a.h.pop() }
+
+// MaxFloat64Interface is an adapter of MaxFloat64 to heap.Interface and sort.Interface.
+type MaxFloat64Interface struct{ h *MaxFloat64 }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *MaxFloat64) Interface() MaxFloat64Interface { return MaxFloat64Interface{h} }
+
+func (a MaxFloat64Interface) Len() int           { return a.h.length() }
+func (a MaxFloat64Interface) Less(i, j int) bool { return a.h.less(i, j) }
+func (a MaxFloat64Interface) Swap(i, j int)      { a.h.swap(i, j) }
+func (a MaxFloat64Interface) Push(x any)         { a.h.push(x.(float64)) }
+func (a MaxFloat64Interface) Pop() any           { return a.h.pop() }
+
+// MinFloat32Interface is an adapter of MinFloat32 to heap.Interface and sort.Interface.
+type MinFloat32Interface struct{ h *MinFloat32 }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *MinFloat32) Interface() MinFloat32Interface { return MinFloat32Interface{h} }
+
+func (a MinFloat32Interface) Len() int           { return a.h.length() }
+func (a MinFloat32Interface) Less(i, j int) bool { return a.h.less(i, j) }
+func (a MinFloat32Interface) Swap(i, j int)      { a.h.swap(i, j) }
+func (a MinFloat32Interface) Push(x any)         { a.h.push(x.(float32)) }
+func (a MinFloat32Interface) Pop() any           { return a.h.pop() }
+
+// MaxFloat32Interface is an adapter of MaxFloat32 to heap.Interface and sort.Interface.
+type MaxFloat32Interface struct{ h *MaxFloat32 }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *MaxFloat32) Interface() MaxFloat32Interface { return MaxFloat32Interface{h} }
+
+func (a MaxFloat32Interface) Len() int           { return a.h.length() }
+func (a MaxFloat32Interface) Less(i, j int) bool { return a.h.less(i, j) }
+func (a MaxFloat32Interface) Swap(i, j int)      { a.h.swap(i, j) }
+func (a MaxFloat32Interface) Push(x any)         { a.h.push(x.(float32)) }
+func (a MaxFloat32Interface) Pop() any           { return a.h.pop() }
+
+// StrFuncInterface is an adapter of StrFunc to heap.Interface and sort.Interface.
+type StrFuncInterface struct{ h *StrFunc }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *StrFunc) Interface() StrFuncInterface { return StrFuncInterface{h} }
+
+func (a StrFuncInterface) Len() int           { return a.h.length() }
+func (a StrFuncInterface) Less(i, j int) bool { return a.h.less(i, j) }
+func (a StrFuncInterface) Swap(i, j int)      { a.h.swap(i, j) }
+func (a StrFuncInterface) Push(x any)         { a.h.push(x.(string)) }
+func (a StrFuncInterface) Pop() any           { return a.h.pop() }
+
+// BytesFuncInterface is an adapter of BytesFunc to heap.Interface and sort.Interface.
+type BytesFuncInterface struct{ h *BytesFunc }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *BytesFunc) Interface() BytesFuncInterface { return BytesFuncInterface{h} }
+
+func (a BytesFuncInterface) Len() int           { return a.h.length() }
+func (a BytesFuncInterface) Less(i, j int) bool { return a.h.less(i, j) }
+func (a BytesFuncInterface) Swap(i, j int)      { a.h.swap(i, j) }
+func (a BytesFuncInterface) Push(x any)         { a.h.push(x.([]byte)) }
+func (a BytesFuncInterface) Pop() any           { return a.h.pop() }
+
+// FuncInterface is an adapter of Func to heap.Interface and sort.Interface.
+type FuncInterface[T any] struct{ h *Func[T] }
+
+// Interface returns an adapter of h to heap.Interface and sort.Interface.
+func (h *Func[T]) Interface() FuncInterface[T] { return FuncInterface[T]{h} }
+
+func (a FuncInterface[T]) Len() int           { return a.h.length() }
+func (a FuncInterface[T]) Less(i, j int) bool { return a.h.less(i, j) }
+func (a FuncInterface[T]) Swap(i, j int)      { a.h.swap(i, j) }
+func (a FuncInterface[T]) Push(x any)         { a.h.push(x.(T)) }
+func (a FuncInterface[T]) Pop() any           { return a.h.pop() }
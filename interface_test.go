@@ -0,0 +1,119 @@
+package heap
+
+import (
+	"cmp"
+	"container/heap"
+	"math"
+	"sort"
+	"testing"
+)
+
+var (
+	_ heap.Interface = MinStrInterface{}
+	_ heap.Interface = MaxStrInterface{}
+	_ heap.Interface = MinBytesInterface{}
+	_ heap.Interface = MaxBytesInterface{}
+	_ heap.Interface = MinInt64Interface{}
+	_ heap.Interface = MaxInt64Interface{}
+	_ heap.Interface = MinUint64Interface{}
+	_ heap.Interface = MaxUint64Interface{}
+	_ heap.Interface = MinFloat64Interface{}
+	_ heap.Interface = MaxFloat64Interface{}
+	_ heap.Interface = MinFloat32Interface{}
+	_ heap.Interface = MaxFloat32Interface{}
+	_ heap.Interface = StrFuncInterface{}
+	_ heap.Interface = BytesFuncInterface{}
+	_ heap.Interface = FuncInterface[int]{}
+)
+
+func TestMinStrInterface(t *testing.T) {
+	h := new(MinStr)
+	for i := 20; i > 10; i-- {
+		h.push(toHex(uint64(i)))
+	}
+	heap.Init(h.Interface())
+	h.verify(t, 0)
+
+	// Elements pushed through the adapter are seen by the heap and vice versa.
+	for i := 10; i > 0; i-- {
+		if i%2 == 0 {
+			heap.Push(h.Interface(), toHex(uint64(i)))
+		} else {
+			h.Push(toHex(uint64(i)))
+		}
+		h.verify(t, 0)
+	}
+	if len(*h) != 20 {
+		t.Fatalf("len(*h) = %d; want 20", len(*h))
+	}
+
+	for i := 1; len(*h) > 0; i++ {
+		var x string
+		if i%2 == 0 {
+			x = heap.Pop(h.Interface()).(string)
+		} else {
+			x = h.Pop()
+		}
+		h.verify(t, 0)
+		if x != toHex(uint64(i)) {
+			t.Errorf("%d.th pop got %s; want %s", i, x, toHex(uint64(i)))
+		}
+	}
+}
+
+func TestMaxInt64InterfaceFix(t *testing.T) {
+	h := new(MaxInt64)
+	for i := 0; i < 10; i++ {
+		heap.Push(h.Interface(), int64(i))
+	}
+	(*h)[0] = -1
+	heap.Fix(h.Interface(), 0)
+	h.verify(t, 0)
+	if x := heap.Remove(h.Interface(), 0).(int64); x != 8 {
+		t.Errorf("Remove(0) got %d; want 8", x)
+	}
+	h.verify(t, 0)
+}
+
+func TestInterfaceSort(t *testing.T) {
+	nan := math.NaN()
+	minf := MinFloat64{3, nan, -1, math.Inf(1), 0}
+	sort.Sort(minf.Interface())
+	minf.verify(t, 0)
+	if minf[0] != -1 || minf[3] != math.Inf(1) || !math.IsNaN(minf[4]) {
+		t.Errorf("sorted MinFloat64 = %v; want [-1 0 3 +Inf NaN]", minf)
+	}
+
+	maxu := MaxUint64{3, 1, 4, 1, 5, 9, 2, 6}
+	sort.Sort(maxu.Interface())
+	maxu.verify(t, 0)
+	if !sort.IsSorted(maxu.Interface()) || maxu[0] != 9 || maxu[7] != 1 {
+		t.Errorf("sorted MaxUint64 = %v; want descending order", maxu)
+	}
+
+	maxb := MaxBytes{[]byte("b"), []byte("c"), []byte("a")}
+	sort.Sort(maxb.Interface())
+	if string(maxb[0]) != "c" || string(maxb[2]) != "a" {
+		t.Errorf("sorted MaxBytes = %q; want [c b a]", maxb)
+	}
+
+	f := &Func[int]{Values: []int{5, 2, 8, 1}, Cmp: cmp.Compare[int]}
+	sort.Sort(f.Interface())
+	f.verify(t, 0)
+	if !sort.IsSorted(f.Interface()) {
+		t.Errorf("sorted Func = %v; want ascending order", f.Values)
+	}
+}
+
+func TestStrFuncInterface(t *testing.T) {
+	h := &StrFunc{Cmp: CompareNatural}
+	for _, s := range []string{"file10", "file2", "file1"} {
+		heap.Push(h.Interface(), s)
+	}
+	h.verify(t, 0)
+	for _, want := range []string{"file1", "file2", "file10"} {
+		if x := heap.Pop(h.Interface()).(string); x != want {
+			t.Errorf("pop got %s; want %s", x, want)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package heap
+
+// IntervalStr is an interval heap for getting both the minimum and
+// the maximum string values. It is a double-ended priority queue which
+// needs no more memory than the slice of elements.
+//
+// Node k of the heap is the pair of elements at index 2*k and 2*k+1,
+// which are the minimum and the maximum of the subtree rooted at node k.
+// The last node has only one element when the heap has an odd number
+// of elements.
+type IntervalStr []string
+
+// Init establishes the heap invariants required by the other methods.
+// Init is idempotent with respect to the heap invariants
+// and may be called whenever the heap invariants may have been invalidated.
+// The complexity is O(n) where n = len(*h).
+func (h *IntervalStr) Init() {
+	n := h.Len()
+	for i := (n+1)/2 - 1; i >= 0; i-- {
+		if 2*i+1 < n {
+			h.maxDown(2*i + 1)
+		}
+		h.minDown(2 * i)
+	}
+}
+
+// Len returns the number of elements in the heap.
+func (h IntervalStr) Len() int { return len(h) }
+
+// PeekMin returns the minimum element of the heap without removing it.
+// The complexity is O(1).
+func (h IntervalStr) PeekMin() string {
+	return h[0]
+}
+
+// PeekMax returns the maximum element of the heap without removing it.
+// The complexity is O(1).
+func (h IntervalStr) PeekMax() string {
+	if len(h) == 1 {
+		return h[0]
+	}
+	return h[1]
+}
+
+// Push pushes the element x onto the heap.
+// The complexity is O(log n) where n = len(*h).
+func (h *IntervalStr) Push(x string) {
+	*h = append(*h, x)
+	a := *h
+	i := len(a) - 1
+	if i%2 == 1 {
+		if a[i] < a[i-1] {
+			a[i], a[i-1] = a[i-1], a[i]
+			h.minUp(i - 1)
+		} else {
+			h.maxUp(i)
+		}
+		return
+	}
+	if i == 0 {
+		return
+	}
+	p := ((i/2 - 1) / 2) * 2 // minimum of the parent node
+	if a[i] < a[p] {
+		h.minUp(i)
+	} else if a[i] > a[p+1] {
+		h.maxUp(i)
+	}
+}
+
+// PopMin removes and returns the minimum element from the heap.
+// The complexity is O(log n) where n = len(*h).
+func (h *IntervalStr) PopMin() string {
+	a := *h
+	n := len(a) - 1
+	x := a[0]
+	a[0] = a[n]
+	*h = a[:n]
+	if n > 0 {
+		h.minDown(0)
+	}
+	return x
+}
+
+// PopMax removes and returns the maximum element from the heap.
+// The complexity is O(log n) where n = len(*h).
+func (h *IntervalStr) PopMax() string {
+	a := *h
+	n := len(a) - 1
+	if n == 0 {
+		*h = a[:0]
+		return a[0]
+	}
+	x := a[1]
+	a[1] = a[n]
+	*h = a[:n]
+	if n > 1 {
+		h.maxDown(1)
+	}
+	return x
+}
+
+// PushPopMin pushes the element x onto the heap and then removes and returns
+// the minimum element from the heap. It is more efficient than Push followed
+// by PopMin. The complexity is O(log n) where n = len(*h).
+func (h *IntervalStr) PushPopMin(x string) string {
+	a := *h
+	if len(a) == 0 || x <= a[0] {
+		return x
+	}
+	x, a[0] = a[0], x
+	h.minDown(0)
+	return x
+}
+
+// PushPopMax pushes the element x onto the heap and then removes and returns
+// the maximum element from the heap. It is more efficient than Push followed
+// by PopMax. The complexity is O(log n) where n = len(*h).
+func (h *IntervalStr) PushPopMax(x string) string {
+	a := *h
+	if len(a) == 0 || x >= h.PeekMax() {
+		return x
+	}
+	if len(a) == 1 {
+		x, a[0] = a[0], x
+		return x
+	}
+	x, a[1] = a[1], x
+	h.maxDown(1)
+	return x
+}
+
+// minUp moves up the element at index i, which is the minimum of its node
+// or the only element of the last node, along the minimums of the ancestors.
+func (h IntervalStr) minUp(i int) {
+	for i > 1 {
+		p := ((i/2 - 1) / 2) * 2
+		if !(h[i] < h[p]) {
+			break
+		}
+		h[i], h[p] = h[p], h[i]
+		i = p
+	}
+}
+
+// maxUp moves up the element at index i, which is the maximum of its node
+// or the only element of the last node, along the maximums of the ancestors.
+func (h IntervalStr) maxUp(i int) {
+	for i > 1 {
+		p := ((i/2-1)/2)*2 + 1
+		if !(h[i] > h[p]) {
+			break
+		}
+		h[i], h[p] = h[p], h[i]
+		i = p
+	}
+}
+
+// minDown moves down the element at index i, which is the minimum of its node,
+// along the minimums of the descendants.
+func (h IntervalStr) minDown(i int) {
+	n := len(h)
+	for {
+		if i+1 < n && h[i+1] < h[i] {
+			h[i], h[i+1] = h[i+1], h[i]
+		}
+		// j is the minimum of the left child node.
+		j := 2*i + 2
+		if j >= n || j < 0 { // j < 0 after int overflow
+			break
+		}
+		if j2 := j + 2; j2 < n && h[j2] < h[j] {
+			j = j2 // minimum of the right child node
+		}
+		if !(h[j] < h[i]) {
+			break
+		}
+		h[i], h[j] = h[j], h[i]
+		i = j
+	}
+}
+
+// maxDown moves down the element at index i, which is the maximum of its node,
+// along the maximums of the descendants.
+func (h IntervalStr) maxDown(i int) {
+	n := len(h)
+	for {
+		if i%2 == 1 && h[i] < h[i-1] {
+			h[i], h[i-1] = h[i-1], h[i]
+		}
+		// j is the maximum of the left child node.
+		j := 2*i + 1
+		if j >= n {
+			j-- // the left child node has only one element
+		}
+		if j >= n || j < 0 { // j < 0 after int overflow
+			break
+		}
+		if j2 := 2*i + 3; j2 < n && h[j2] > h[j] {
+			j = j2 // maximum of the right child node
+		} else if j2 == n && h[j2-1] > h[j] {
+			j = j2 - 1 // the right child node has only one element
+		}
+		if !(h[j] > h[i]) {
+			break
+		}
+		h[i], h[j] = h[j], h[i]
+		i = j
+	}
+}
@@ -0,0 +1,179 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func (h IntervalStr) verify(t *testing.T) {
+	t.Helper()
+	n := len(h)
+	for i := 0; i < n; i += 2 {
+		lo, hi := i, i
+		if i+1 < n {
+			hi = i + 1
+		}
+		if h[hi] < h[lo] {
+			t.Errorf("heap invariant invalidated [%d] = %s > [%d] = %s", lo, h[lo], hi, h[hi])
+			return
+		}
+		if i == 0 {
+			continue
+		}
+		p := ((i/2 - 1) / 2) * 2
+		if h[lo] < h[p] {
+			t.Errorf("heap invariant invalidated [%d] = %s > [%d] = %s", p, h[p], lo, h[lo])
+			return
+		}
+		if h[hi] > h[p+1] {
+			t.Errorf("heap invariant invalidated [%d] = %s < [%d] = %s", p+1, h[p+1], hi, h[hi])
+			return
+		}
+	}
+}
+
+func TestIntervalStrInit0(t *testing.T) {
+	h := new(IntervalStr)
+	for i := 20; i > 0; i-- {
+		h.Push("0") // all elements are the same
+	}
+	h.Init()
+	h.verify(t)
+
+	for i := 1; h.Len() > 0; i++ {
+		x := h.PopMin()
+		h.verify(t)
+		if x != "0" {
+			t.Errorf("%d.th pop got %s; want %s", i, x, "0")
+		}
+	}
+}
+
+func TestIntervalStrInit1(t *testing.T) {
+	for n := 0; n < 40; n++ {
+		h := make(IntervalStr, n)
+		for i, v := range rand.Perm(n) {
+			h[i] = toHex(uint64(v))
+		}
+		h.Init()
+		h.verify(t)
+
+		for i := 0; h.Len() > 0; i++ {
+			min := h.PopMin()
+			h.verify(t)
+			if min != toHex(uint64(i)) {
+				t.Errorf("%d.th PopMin got %s; want %s", i, min, toHex(uint64(i)))
+			}
+			if h.Len() == 0 {
+				break
+			}
+			max := h.PopMax()
+			h.verify(t)
+			if want := toHex(uint64(n - 1 - i)); max != want {
+				t.Errorf("%d.th PopMax got %s; want %s", i, max, want)
+			}
+		}
+	}
+}
+
+func TestIntervalStr(t *testing.T) {
+	h := new(IntervalStr)
+	var sorted []string
+	for i := 0; i < 2000; i++ {
+		switch op := rand.Intn(6); {
+		case op < 2 || len(sorted) == 0:
+			x := toHex(uint64(rand.Int63n(100)))
+			h.Push(x)
+			sorted = append(sorted, x)
+			sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		case op == 2:
+			if x, want := h.PopMin(), sorted[0]; x != want {
+				t.Fatalf("PopMin got %s; want %s", x, want)
+			}
+			sorted = sorted[1:]
+		case op == 3:
+			if x, want := h.PopMax(), sorted[len(sorted)-1]; x != want {
+				t.Fatalf("PopMax got %s; want %s", x, want)
+			}
+			sorted = sorted[:len(sorted)-1]
+		case op == 4:
+			x := toHex(uint64(rand.Int63n(100)))
+			sorted = append(sorted, x)
+			sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+			if got, want := h.PushPopMin(x), sorted[0]; got != want {
+				t.Fatalf("PushPopMin(%s) got %s; want %s", x, got, want)
+			}
+			sorted = sorted[1:]
+		case op == 5:
+			x := toHex(uint64(rand.Int63n(100)))
+			sorted = append(sorted, x)
+			sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+			if got, want := h.PushPopMax(x), sorted[len(sorted)-1]; got != want {
+				t.Fatalf("PushPopMax(%s) got %s; want %s", x, got, want)
+			}
+			sorted = sorted[:len(sorted)-1]
+		}
+		h.verify(t)
+		if h.Len() != len(sorted) {
+			t.Fatalf("Len() = %d; want %d", h.Len(), len(sorted))
+		}
+		if len(sorted) > 0 {
+			if x, want := h.PeekMin(), sorted[0]; x != want {
+				t.Fatalf("PeekMin got %s; want %s", x, want)
+			}
+			if x, want := h.PeekMax(), sorted[len(sorted)-1]; x != want {
+				t.Fatalf("PeekMax got %s; want %s", x, want)
+			}
+		}
+	}
+}
+
+func BenchmarkIntervalStr(b *testing.B) {
+	const n = 10000
+	values := make([]string, n)
+	for i := range values {
+		values[i] = toHex(rand.Uint64())
+	}
+
+	b.Run("interval", func(b *testing.B) {
+		h := make(IntervalStr, 0, n)
+		for i := 0; i < b.N; i++ {
+			for _, v := range values {
+				h.Push(v)
+			}
+			for h.Len() > 1 {
+				h.PopMin()
+				h.PopMax()
+			}
+			h = h[:0]
+		}
+	})
+	b.Run("minmax", func(b *testing.B) {
+		// A MinStr and a MaxStr side by side, where an element popped
+		// from one heap is deleted lazily from the other.
+		minh := make(MinStr, 0, n)
+		maxh := make(MaxStr, 0, n)
+		deleted := make(map[string]int)
+		for i := 0; i < b.N; i++ {
+			for _, v := range values {
+				minh.Push(v)
+				maxh.Push(v)
+			}
+			for m := n; m > 1; m -= 2 {
+				for deleted[minh[0]] > 0 {
+					deleted[minh.Pop()]--
+				}
+				deleted[minh.Pop()]++
+				for deleted[maxh[0]] > 0 {
+					deleted[maxh.Pop()]--
+				}
+				deleted[maxh.Pop()]++
+			}
+			minh, maxh = minh[:0], maxh[:0]
+			for k := range deleted {
+				delete(deleted, k)
+			}
+		}
+	})
+}
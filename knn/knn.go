@@ -0,0 +1,110 @@
+// Package knn provides k-nearest-neighbour search over candidates whose
+// distances are computed by the caller, keeping the k closest candidates
+// in a bounded max-heap.
+package knn
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/hnakamur/heap"
+)
+
+// Neighbor is a candidate found by a search and its distance.
+type Neighbor[ID cmp.Ordered] struct {
+	ID   ID
+	Dist float64
+}
+
+// compareNeighbor orders neighbors by distance, and by ID for equal distances.
+func compareNeighbor[ID cmp.Ordered](a, b Neighbor[ID]) int {
+	if c := cmp.Compare(a.Dist, b.Dist); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+// Nearest keeps the k nearest candidates added to it. Candidates are ordered
+// by distance, and candidates with equal distances are ordered by ID, so the
+// result does not depend on the order in which candidates are added.
+type Nearest[ID cmp.Ordered] struct {
+	k int
+	h heap.Func[Neighbor[ID]] // the farthest neighbor at the top
+}
+
+// New returns an empty Nearest which keeps k candidates.
+// New panics if k is negative.
+func New[ID cmp.Ordered](k int) *Nearest[ID] {
+	if k < 0 {
+		panic("knn: negative k")
+	}
+	return &Nearest[ID]{
+		k: k,
+		h: heap.Func[Neighbor[ID]]{
+			Values: make([]Neighbor[ID], 0, k),
+			Cmp:    func(a, b Neighbor[ID]) int { return compareNeighbor(b, a) },
+		},
+	}
+}
+
+// Len returns the number of candidates kept, which is at most k.
+func (n *Nearest[ID]) Len() int { return len(n.h.Values) }
+
+// Bound returns the distance of the farthest candidate kept if k candidates
+// are kept, +Inf if fewer are kept, and -Inf if k is 0. A candidate farther
+// than Bound is never kept, so computing its exact distance can be skipped.
+func (n *Nearest[ID]) Bound() float64 {
+	if n.k == 0 {
+		return math.Inf(-1)
+	}
+	if len(n.h.Values) < n.k {
+		return math.Inf(1)
+	}
+	return n.h.Values[0].Dist
+}
+
+// Add adds the candidate id at the distance dist and reports whether it is kept.
+// It may drop the farthest candidate kept so far. Candidates whose distances
+// are NaN are never kept.
+// The complexity is O(log k).
+func (n *Nearest[ID]) Add(id ID, dist float64) bool {
+	if math.IsNaN(dist) || n.k == 0 {
+		return false
+	}
+	c := Neighbor[ID]{ID: id, Dist: dist}
+	if len(n.h.Values) < n.k {
+		n.h.Push(c)
+		return true
+	}
+	if compareNeighbor(c, n.h.Values[0]) >= 0 {
+		return false
+	}
+	n.h.Values[0] = c
+	n.h.Fix(0)
+	return true
+}
+
+// Results returns the candidates kept in ascending order of distances,
+// with ties broken by ascending IDs.
+// The complexity is O(k log k).
+func (n *Nearest[ID]) Results() []Neighbor[ID] {
+	ret := slices.Clone(n.h.Values)
+	slices.SortFunc(ret, compareNeighbor[ID])
+	return ret
+}
+
+// Search returns the k nearest candidates yielded by candidates in ascending
+// order of distances, with ties broken by ascending IDs. The distance of each
+// candidate is computed by dist, typically to a query vector captured by it.
+// Candidates whose distances are NaN are skipped.
+//
+// The complexity is O(n log k) where n is the number of candidates.
+func Search[ID cmp.Ordered, T any](k int, candidates func(yield func(id ID, item T) bool), dist func(item T) float64) []Neighbor[ID] {
+	n := New[ID](k)
+	candidates(func(id ID, item T) bool {
+		n.Add(id, dist(item))
+		return true
+	})
+	return n.Results()
+}
@@ -0,0 +1,141 @@
+package knn
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type point struct{ x, y float64 }
+
+func squaredDist(p, q point) float64 {
+	dx, dy := p.x-q.x, p.y-q.y
+	return dx*dx + dy*dy
+}
+
+func pointsSeq(points []point) func(yield func(id int, p point) bool) {
+	return func(yield func(id int, p point) bool) {
+		for i, p := range points {
+			if !yield(i, p) {
+				return
+			}
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	points := make([]point, 1000)
+	for i := range points {
+		// Coarse coordinates make many candidates at equal distances.
+		points[i] = point{float64(rand.Intn(20)), float64(rand.Intn(20))}
+	}
+	for _, k := range []int{0, 1, 5, 100, 1000, 2000} {
+		q := point{float64(rand.Intn(20)), float64(rand.Intn(20))}
+		dist := func(p point) float64 { return squaredDist(p, q) }
+
+		want := make([]Neighbor[int], len(points))
+		for i, p := range points {
+			want[i] = Neighbor[int]{ID: i, Dist: dist(p)}
+		}
+		slices.SortStableFunc(want, compareNeighbor[int])
+		want = want[:min(k, len(want))]
+
+		got := Search(k, pointsSeq(points), dist)
+		if !slices.Equal(got, want) {
+			t.Errorf("Search(k=%d) = %v; want %v", k, got, want)
+		}
+	}
+}
+
+func TestNearestTies(t *testing.T) {
+	n := New[string](2)
+	for _, id := range []string{"d", "b", "c", "a"} {
+		n.Add(id, 1)
+	}
+	want := []Neighbor[string]{{"a", 1}, {"b", 1}}
+	if got := n.Results(); !slices.Equal(got, want) {
+		t.Errorf("Results() = %v; want %v", got, want)
+	}
+}
+
+func TestNearestBound(t *testing.T) {
+	n := New[int](2)
+	if b := n.Bound(); !math.IsInf(b, 1) {
+		t.Errorf("Bound() = %g; want +Inf", b)
+	}
+	n.Add(1, 3)
+	if b := n.Bound(); !math.IsInf(b, 1) {
+		t.Errorf("Bound() = %g; want +Inf", b)
+	}
+	n.Add(2, 5)
+	if b := n.Bound(); b != 5 {
+		t.Errorf("Bound() = %g; want 5", b)
+	}
+	if n.Add(3, 6) {
+		t.Error("Add(3, 6) = true; want false")
+	}
+	if !n.Add(4, 4) {
+		t.Error("Add(4, 4) = false; want true")
+	}
+	if b := n.Bound(); b != 4 {
+		t.Errorf("Bound() = %g; want 4", b)
+	}
+	if n.Len() != 2 {
+		t.Errorf("Len() = %d; want 2", n.Len())
+	}
+
+	if b := New[int](0).Bound(); !math.IsInf(b, -1) {
+		t.Errorf("Bound() with k = 0 is %g; want -Inf", b)
+	}
+}
+
+func TestNearestNaN(t *testing.T) {
+	n := New[int](3)
+	n.Add(1, math.NaN())
+	n.Add(2, 2)
+	n.Add(3, math.Inf(1))
+	want := []Neighbor[int]{{2, 2}, {3, math.Inf(1)}}
+	if got := n.Results(); !slices.Equal(got, want) {
+		t.Errorf("Results() = %v; want %v", got, want)
+	}
+}
+
+func TestNewNegative(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("New(-1) did not panic")
+		}
+	}()
+	New[int](-1)
+}
+
+func BenchmarkSearch(b *testing.B) {
+	const dim = 32
+	vectors := make([][]float64, 100_000)
+	for i := range vectors {
+		vectors[i] = make([]float64, dim)
+		for j := range vectors[i] {
+			vectors[i][j] = rand.Float64()
+		}
+	}
+	q := vectors[0]
+	seq := func(yield func(id int, v []float64) bool) {
+		for i, v := range vectors {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+	dist := func(v []float64) float64 {
+		var d float64
+		for j, x := range v {
+			d += (x - q[j]) * (x - q[j])
+		}
+		return d
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Search(10, seq, dist)
+	}
+}
@@ -0,0 +1,92 @@
+package heap
+
+import "cmp"
+
+// Leftist is a persistent leftist heap for getting the minimum key.
+// Leftist is immutable: Push, Pop, and Merge return new versions of the heap
+// and leave the receiver unchanged. A new version shares all but O(log n)
+// nodes with the old one, so keeping old versions as snapshots is cheap.
+//
+// The zero value for Leftist is an empty heap ready to use.
+// Leftist values may be copied and used concurrently by multiple goroutines.
+type Leftist[K cmp.Ordered] struct {
+	root *leftistNode[K]
+	n    int
+}
+
+type leftistNode[K cmp.Ordered] struct {
+	key   K
+	rank  int // length of the right spine
+	left  *leftistNode[K]
+	right *leftistNode[K]
+}
+
+func (x *leftistNode[K]) getRank() int {
+	if x == nil {
+		return 0
+	}
+	return x.rank
+}
+
+// Len returns the number of elements in the heap.
+func (h Leftist[K]) Len() int { return h.n }
+
+// Min returns the minimum element of the heap.
+// Min panics if the heap is empty.
+// The complexity is O(1).
+func (h Leftist[K]) Min() K {
+	if h.root == nil {
+		panic("heap: Min on empty Leftist")
+	}
+	return h.root.key
+}
+
+// Push returns a new version of the heap with the element x added.
+// The complexity is O(log n) where n = h.Len().
+func (h Leftist[K]) Push(x K) Leftist[K] {
+	return Leftist[K]{
+		root: mergeLeftist(h.root, &leftistNode[K]{key: x, rank: 1}),
+		n:    h.n + 1,
+	}
+}
+
+// Pop returns the minimum element and a new version of the heap with
+// the element removed. Pop panics if the heap is empty.
+// The complexity is O(log n) where n = h.Len().
+func (h Leftist[K]) Pop() (K, Leftist[K]) {
+	if h.root == nil {
+		panic("heap: Pop on empty Leftist")
+	}
+	return h.root.key, Leftist[K]{
+		root: mergeLeftist(h.root.left, h.root.right),
+		n:    h.n - 1,
+	}
+}
+
+// Merge returns a new version of the heap with all the elements of h and other.
+// The complexity is O(log n) where n = h.Len() + other.Len().
+func (h Leftist[K]) Merge(other Leftist[K]) Leftist[K] {
+	return Leftist[K]{
+		root: mergeLeftist(h.root, other.root),
+		n:    h.n + other.n,
+	}
+}
+
+// mergeLeftist merges the heaps a and b along their right spines.
+// It copies the nodes on the path and never modifies existing nodes.
+func mergeLeftist[K cmp.Ordered](a, b *leftistNode[K]) *leftistNode[K] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if b.key < a.key {
+		a, b = b, a
+	}
+	l, r := a.left, mergeLeftist(a.right, b)
+	if l.getRank() < r.getRank() {
+		l, r = r, l
+	}
+	return &leftistNode[K]{key: a.key, rank: r.getRank() + 1, left: l, right: r}
+}
@@ -0,0 +1,121 @@
+package heap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func (h Leftist[K]) verify(t *testing.T) {
+	t.Helper()
+	var walk func(x *leftistNode[K]) int
+	walk = func(x *leftistNode[K]) int {
+		if x == nil {
+			return 0
+		}
+		for _, c := range []*leftistNode[K]{x.left, x.right} {
+			if c != nil && c.key < x.key {
+				t.Errorf("heap invariant invalidated %v > %v", x.key, c.key)
+			}
+		}
+		if x.left.getRank() < x.right.getRank() {
+			t.Errorf("leftist property invalidated at %v", x.key)
+		}
+		if x.rank != x.right.getRank()+1 {
+			t.Errorf("node %v has rank %d; want %d", x.key, x.rank, x.right.getRank()+1)
+		}
+		return 1 + walk(x.left) + walk(x.right)
+	}
+	if n := walk(h.root); n != h.n {
+		t.Errorf("heap has %d nodes; want %d", n, h.n)
+	}
+}
+
+// elements pops all the elements from a copy of h.
+func (h Leftist[K]) elements() []K {
+	var s []K
+	for h.Len() > 0 {
+		var x K
+		x, h = h.Pop()
+		s = append(s, x)
+	}
+	return s
+}
+
+func TestLeftist(t *testing.T) {
+	var h Leftist[int64]
+	h.verify(t)
+
+	for i := 20; i > 0; i-- {
+		h = h.Push(int64(i))
+		h.verify(t)
+	}
+
+	for i := 1; h.Len() > 0; i++ {
+		if x := h.Min(); x != int64(i) {
+			t.Errorf("%d.th min got %d; want %d", i, x, int64(i))
+		}
+		var x int64
+		x, h = h.Pop()
+		h.verify(t)
+		if x != int64(i) {
+			t.Errorf("%d.th pop got %d; want %d", i, x, int64(i))
+		}
+	}
+}
+
+func TestLeftistPersistent(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	// versions[i] and want[i] are the i-th version of the heap and
+	// its elements in sorted order.
+	versions := []Leftist[int]{{}}
+	want := [][]int{nil}
+	for i := 0; i < 300; i++ {
+		j := rnd.Intn(len(versions))
+		h, elems := versions[j], slices.Clone(want[j])
+		switch op := rnd.Intn(3); {
+		case op == 0 || h.Len() == 0:
+			x := rnd.Intn(100)
+			h = h.Push(x)
+			elems = append(elems, x)
+			slices.Sort(elems)
+		case op == 1:
+			var x int
+			x, h = h.Pop()
+			if x != elems[0] {
+				t.Fatalf("Pop of version %d got %d; want %d", j, x, elems[0])
+			}
+			elems = elems[1:]
+		default:
+			k := rnd.Intn(len(versions))
+			h = h.Merge(versions[k])
+			elems = append(elems, want[k]...)
+			slices.Sort(elems)
+		}
+		h.verify(t)
+		versions = append(versions, h)
+		want = append(want, elems)
+	}
+
+	// All the old versions must remain unchanged.
+	for i, h := range versions {
+		h.verify(t)
+		if h.Len() != len(want[i]) {
+			t.Errorf("version %d has Len() = %d; want %d", i, h.Len(), len(want[i]))
+		}
+		if got := h.elements(); !slices.Equal(got, want[i]) {
+			t.Errorf("version %d has elements %v; want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestLeftistPopEmpty(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Pop on empty Leftist did not panic")
+		}
+	}()
+	var h Leftist[string]
+	h.Pop()
+}
@@ -0,0 +1,106 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package heap
+
+// MaxFloat32 is a heap for getting the maximum float32 value.
+// NaNs are ordered after all other values including -Inf,
+// so they are popped last, and +0.0 is ordered before -0.0.
+type MaxFloat32 []float32
+
+// Init establishes the heap invariants required by the other routines in this package.
+// Init is idempotent with respect to the heap invariants
+// and may be called whenever the heap invariants may have been invalidated.
+// The complexity is O(n) where n = len(*h).
+func (h *MaxFloat32) Init() {
+	// heapify
+	n := h.length()
+	for i := n/2 - 1; i >= 0; i-- {
+		h.down(i, n)
+	}
+}
+
+// Push pushes the element x onto the heap.
+// The complexity is O(log n) where n = len(*h).
+func (h *MaxFloat32) Push(x float32) {
+	h.push(x)
+	h.up(h.length() - 1)
+}
+
+// Pop removes and returns the maximum element from the heap.
+// The complexity is O(log n) where n = len(*h).
+// Pop is equivalent to Remove(h, 0).
+func (h *MaxFloat32) Pop() float32 {
+	n := h.length() - 1
+	h.swap(0, n)
+	h.down(0, n)
+	return h.pop()
+}
+
+// Remove removes and returns the element at index i from the heap.
+// The complexity is O(log n) where n = len(*h).
+func (h *MaxFloat32) Remove(i int) float32 {
+	n := h.length() - 1
+	if n != i {
+		h.swap(i, n)
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+	return h.pop()
+}
+
+// Fix re-establishes the heap ordering after the element at index i has changed its value.
+// Changing the value of the element at index i and then calling Fix is equivalent to,
+// but less expensive than, calling Remove(h, i) followed by a Push of the new value.
+// The complexity is O(log n) where n = len(*h).
+func (h *MaxFloat32) Fix(i int) {
+	if !h.down(i, h.length()) {
+		h.up(i)
+	}
+}
+
+func (h *MaxFloat32) up(j int) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		j = i
+	}
+}
+
+func (h *MaxFloat32) down(i0, n int) bool {
+	i := i0
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && h.less(j2, j1) {
+			j = j2 // = 2*i + 2  // right child
+		}
+		if !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		i = j
+	}
+	return i > i0
+}
+
+func (h MaxFloat32) length() int        { return len(h) }
+func (h MaxFloat32) less(i, j int) bool { return greaterFloat32(h[i], h[j]) }
+func (h MaxFloat32) swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *MaxFloat32) push(x float32) {
+	*h = append(*h, x)
+}
+
+func (h *MaxFloat32) pop() (x float32) {
+	*h, x = (*h)[:h.length()-1], (*h)[h.length()-1]
+	return
+}
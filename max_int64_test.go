@@ -190,3 +190,9 @@ func TestMaxInt64Fix(t *testing.T) {
 		h.verify(t, 0)
 	}
 }
+
+func FuzzMaxInt64(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzHeap[int64, MaxInt64](t, data, (*fuzzReader).int64, fuzzOracle[int64].max)
+	})
+}
@@ -190,3 +190,9 @@ func TestMaxStrFix(t *testing.T) {
 		h.verify(t, 0)
 	}
 }
+
+func FuzzMaxStr(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzHeap[string, MaxStr](t, data, (*fuzzReader).str, fuzzOracle[string].max)
+	})
+}
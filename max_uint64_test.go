@@ -190,3 +190,9 @@ func TestMaxUint64Fix(t *testing.T) {
 		h.verify(t, 0)
 	}
 }
+
+func FuzzMaxUint64(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzHeap[uint64, MaxUint64](t, data, (*fuzzReader).uint64, fuzzOracle[uint64].max)
+	})
+}
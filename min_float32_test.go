@@ -0,0 +1,223 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package heap
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func (h *MinFloat32) verify(t *testing.T, i int) {
+	t.Helper()
+	n := h.length()
+	j1 := 2*i + 1
+	j2 := 2*i + 2
+	if j1 < n {
+		if h.less(j1, i) {
+			t.Errorf("heap invariant invalidated [%d] = %g > [%d] = %g", i, (*h)[i], j1, (*h)[j1])
+			return
+		}
+		h.verify(t, j1)
+	}
+	if j2 < n {
+		if h.less(j2, i) {
+			t.Errorf("heap invariant invalidated [%d] = %g > [%d] = %g", i, (*h)[i], j1, (*h)[j2])
+			return
+		}
+		h.verify(t, j2)
+	}
+}
+
+func TestMinFloat32Init0(t *testing.T) {
+	h := new(MinFloat32)
+	for i := 20; i > 0; i-- {
+		h.Push(0) // all elements are the same
+	}
+	h.Init()
+	h.verify(t, 0)
+
+	for i := 1; h.length() > 0; i++ {
+		x := h.Pop()
+		h.verify(t, 0)
+		if x != 0 {
+			t.Errorf("%d.th pop got %g; want %g", i, x, 0.0)
+		}
+	}
+}
+
+func TestMinFloat32Init1(t *testing.T) {
+	h := new(MinFloat32)
+	for i := 20; i > 0; i-- {
+		h.Push(float32(i)) // all elements are different
+	}
+	h.Init()
+	h.verify(t, 0)
+
+	for i := 1; h.length() > 0; i++ {
+		x := h.Pop()
+		h.verify(t, 0)
+		if x != float32(i) {
+			t.Errorf("%d.th pop got %g; want %g", i, x, float32(i))
+		}
+	}
+}
+
+func TestMinFloat32(t *testing.T) {
+	h := new(MinFloat32)
+	h.verify(t, 0)
+
+	for i := 20; i > 10; i-- {
+		h.push(float32(i))
+	}
+	h.Init()
+	h.verify(t, 0)
+
+	for i := 10; i > 0; i-- {
+		h.Push(float32(i))
+		h.verify(t, 0)
+	}
+
+	for i := 1; h.length() > 0; i++ {
+		x := h.Pop()
+		if i < 20 {
+			h.Push(float32(20 + i))
+		}
+		h.verify(t, 0)
+		if x != float32(i) {
+			t.Errorf("%d.th pop got %g; want %g", i, x, float32(i))
+		}
+	}
+}
+
+func TestMinFloat32Remove0(t *testing.T) {
+	h := new(MinFloat32)
+	for i := 0; i < 10; i++ {
+		h.push(float32(i))
+	}
+	h.verify(t, 0)
+
+	for h.length() > 0 {
+		i := h.length() - 1
+		x := h.Remove(i)
+		if x != float32(i) {
+			t.Errorf("Remove(%d) got %g; want %g", i, x, float32(i))
+		}
+		h.verify(t, 0)
+	}
+}
+
+func TestMinFloat32Remove1(t *testing.T) {
+	h := new(MinFloat32)
+	for i := 0; i < 10; i++ {
+		h.push(float32(i))
+	}
+	h.verify(t, 0)
+
+	for i := 0; h.length() > 0; i++ {
+		x := h.Remove(0)
+		if x != float32(i) {
+			t.Errorf("Remove(0) got %g; want %g", x, float32(i))
+		}
+		h.verify(t, 0)
+	}
+}
+
+func TestMinFloat32Remove2(t *testing.T) {
+	N := 10
+
+	h := new(MinFloat32)
+	for i := 0; i < N; i++ {
+		h.push(float32(i))
+	}
+	h.verify(t, 0)
+
+	m := make(map[float32]bool)
+	for h.length() > 0 {
+		m[h.Remove((h.length()-1)/2)] = true
+		h.verify(t, 0)
+	}
+
+	if len(m) != N {
+		t.Errorf("len(m) = %d; want %d", len(m), N)
+	}
+	for i := 0; i < len(m); i++ {
+		k := float32(i)
+		if !m[k] {
+			t.Errorf("m[%g] doesn't exist", k)
+		}
+	}
+}
+
+func BenchmarkMinFloat32Dup(b *testing.B) {
+	const n = 10000
+	h := make(MinFloat32, 0, n)
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < n; j++ {
+			h.Push(0) // all elements are the same
+		}
+		for h.length() > 0 {
+			h.Pop()
+		}
+	}
+}
+
+func TestMinFloat32Fix(t *testing.T) {
+	h := new(MinFloat32)
+	h.verify(t, 0)
+
+	for i := 200; i > 0; i -= 10 {
+		h.Push(float32(i))
+	}
+	h.verify(t, 0)
+
+	if (*h)[0] != 10 {
+		t.Fatalf("Expected head to be 10, was %g", (*h)[0])
+	}
+	(*h)[0] = 210
+	h.Fix(0)
+	h.verify(t, 0)
+
+	for i := 100; i > 0; i-- {
+		elem := rand.Intn(h.length())
+		if i&1 == 0 {
+			(*h)[elem] *= 2
+		} else {
+			(*h)[elem] /= 2
+		}
+		h.Fix(elem)
+		h.verify(t, 0)
+	}
+}
+
+func TestMinFloat32NaN(t *testing.T) {
+	nan := float32(math.NaN())
+	negZero := float32(math.Copysign(0, -1))
+	values := []float32{nan, 1, float32(math.Inf(1)), 0, nan, -1, negZero, float32(math.Inf(-1)), nan, 0, negZero}
+	want := []float32{float32(math.Inf(-1)), -1, negZero, negZero, 0, 0, 1, float32(math.Inf(1)), nan, nan, nan}
+
+	h := new(MinFloat32)
+	for _, v := range values {
+		h.Push(v)
+		h.verify(t, 0)
+	}
+	for i := 0; h.length() > 0; i++ {
+		x := h.Pop()
+		h.verify(t, 0)
+		if math.Float32bits(x) != math.Float32bits(want[i]) {
+			t.Errorf("%d.th pop got %g; want %g", i, x, want[i])
+		}
+	}
+
+	*h = append((*h)[:0], values...)
+	h.Init()
+	h.verify(t, 0)
+	for i := 0; h.length() > 0; i++ {
+		x := h.Pop()
+		if math.Float32bits(x) != math.Float32bits(want[i]) {
+			t.Errorf("%d.th pop after Init got %g; want %g", i, x, want[i])
+		}
+	}
+}
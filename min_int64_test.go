@@ -190,3 +190,9 @@ func TestMinInt64Fix(t *testing.T) {
 		h.verify(t, 0)
 	}
 }
+
+func FuzzMinInt64(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzHeap[int64, MinInt64](t, data, (*fuzzReader).int64, fuzzOracle[int64].min)
+	})
+}
@@ -207,3 +207,9 @@ func fromHex(t *testing.T, h string) uint64 {
 	}
 	return binary.BigEndian.Uint64(b)
 }
+
+func FuzzMinStr(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzHeap[string, MinStr](t, data, (*fuzzReader).str, fuzzOracle[string].min)
+	})
+}
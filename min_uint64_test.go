@@ -190,3 +190,9 @@ func TestMinUint64Fix(t *testing.T) {
 		h.verify(t, 0)
 	}
 }
+
+func FuzzMinUint64(f *testing.F) {
+	f.Fuzz(func(t *testing.T, data []byte) {
+		fuzzHeap[uint64, MinUint64](t, data, (*fuzzReader).uint64, fuzzOracle[uint64].min)
+	})
+}
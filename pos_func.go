@@ -0,0 +1,133 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package heap
+
+// Func is a heap for getting the minimum value of type T ordered by Cmp.
+// It is useful for elements which carry data along with their keys,
+// such as a key and the index of the source it was read from.
+type PosFunc[T any] struct {
+	// Values holds the elements of the heap.
+	Values []T
+
+	// Cmp returns a negative number when a is less than b, zero when a equals b,
+	// and a positive number when a is greater than b, like cmp.Compare.
+	// The maximum version of heap can be made by negating the result.
+	Cmp func(a, b T) int
+
+	// SetPos is called with the element x and its new index i whenever
+	// x is put in Values or moved in Values, and with i = -1 when x is
+	// removed from the heap.
+	SetPos func(x T, i int)
+}
+
+// Init establishes the heap invariants required by the other routines in this package.
+// Init is idempotent with respect to the heap invariants
+// and may be called whenever the heap invariants may have been invalidated.
+// The complexity is O(n) where n = len(h.Values).
+func (h *PosFunc[T]) Init() {
+	for i, x := range h.Values {
+		h.SetPos(x, i)
+	}
+	// heapify
+	n := h.length()
+	for i := n/2 - 1; i >= 0; i-- {
+		h.down(i, n)
+	}
+}
+
+// Push pushes the element x onto the heap.
+// The complexity is O(log n) where n = len(h.Values).
+func (h *PosFunc[T]) Push(x T) {
+	h.push(x)
+	h.up(h.length() - 1)
+}
+
+// Pop removes and returns the minimum element from the heap.
+// The complexity is O(log n) where n = len(h.Values).
+// Pop is equivalent to Remove(h, 0).
+func (h *PosFunc[T]) Pop() T {
+	n := h.length() - 1
+	h.swap(0, n)
+	h.down(0, n)
+	return h.pop()
+}
+
+// Remove removes and returns the element at index i from the heap.
+// The complexity is O(log n) where n = len(h.Values).
+func (h *PosFunc[T]) Remove(i int) T {
+	n := h.length() - 1
+	if n != i {
+		h.swap(i, n)
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+	return h.pop()
+}
+
+// Fix re-establishes the heap ordering after the element at index i has changed its value.
+// Changing the value of the element at index i and then calling Fix is equivalent to,
+// but less expensive than, calling Remove(h, i) followed by a Push of the new value.
+// The complexity is O(log n) where n = len(h.Values).
+func (h *PosFunc[T]) Fix(i int) {
+	if !h.down(i, h.length()) {
+		h.up(i)
+	}
+}
+
+func (h *PosFunc[T]) up(j int) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		j = i
+	}
+}
+
+func (h *PosFunc[T]) down(i0, n int) bool {
+	i := i0
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && h.less(j2, j1) {
+			j = j2 // = 2*i + 2  // right child
+		}
+		if !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		i = j
+	}
+	return i > i0
+}
+
+func (h *PosFunc[T]) length() int        { return len(h.Values) }
+func (h *PosFunc[T]) less(i, j int) bool { return h.Cmp(h.Values[i], h.Values[j]) < 0 }
+
+func (h *PosFunc[T]) swap(i, j int) {
+	h.Values[i], h.Values[j] = h.Values[j], h.Values[i]
+	h.SetPos(h.Values[i], i)
+	h.SetPos(h.Values[j], j)
+}
+
+func (h *PosFunc[T]) push(x T) {
+	h.Values = append(h.Values, x)
+	h.SetPos(x, len(h.Values)-1)
+}
+
+func (h *PosFunc[T]) pop() (x T) {
+	n := h.length() - 1
+	x = h.Values[n]
+	var zero T
+	h.Values[n] = zero // release the element for the garbage collector
+	h.Values = h.Values[:n]
+	h.SetPos(x, -1)
+	return
+}
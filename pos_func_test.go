@@ -0,0 +1,113 @@
+package heap
+
+import (
+	"cmp"
+	"math/rand"
+	"testing"
+)
+
+func (h *PosFunc[T]) verify(t *testing.T, i int) {
+	t.Helper()
+	n := h.length()
+	j1 := 2*i + 1
+	j2 := 2*i + 2
+	if j1 < n {
+		if h.less(j1, i) {
+			t.Errorf("heap invariant invalidated [%d] = %v > [%d] = %v", i, h.Values[i], j1, h.Values[j1])
+			return
+		}
+		h.verify(t, j1)
+	}
+	if j2 < n {
+		if h.less(j2, i) {
+			t.Errorf("heap invariant invalidated [%d] = %v > [%d] = %v", i, h.Values[i], j1, h.Values[j2])
+			return
+		}
+		h.verify(t, j2)
+	}
+}
+
+// posFuncTest is a heap of ids ordered by their keys, which records
+// the positions of the ids in a slice.
+type posFuncTest struct {
+	PosFunc[int]
+	keys []int
+	pos  []int
+}
+
+func newPosFuncTest(n int) *posFuncTest {
+	h := &posFuncTest{keys: make([]int, n), pos: make([]int, n)}
+	for id := range h.pos {
+		h.pos[id] = -1
+	}
+	h.Cmp = func(a, b int) int { return cmp.Compare(h.keys[a], h.keys[b]) }
+	h.SetPos = func(id, i int) { h.pos[id] = i }
+	return h
+}
+
+func (h *posFuncTest) verifyPos(t *testing.T) {
+	t.Helper()
+	h.verify(t, 0)
+	in := make([]bool, len(h.pos))
+	for i, id := range h.Values {
+		in[id] = true
+		if h.pos[id] != i {
+			t.Errorf("pos[%d] = %d; want %d", id, h.pos[id], i)
+		}
+	}
+	for id, p := range h.pos {
+		if !in[id] && p != -1 {
+			t.Errorf("pos[%d] = %d for a removed id; want -1", id, p)
+		}
+	}
+}
+
+func TestPosFunc(t *testing.T) {
+	h := newPosFuncTest(20)
+	for id := range h.keys {
+		h.keys[id] = 20 - id
+		h.Push(id)
+		h.verifyPos(t)
+	}
+	for i := 1; h.length() > 0; i++ {
+		id := h.Pop()
+		h.verifyPos(t)
+		if h.keys[id] != i {
+			t.Errorf("%d.th pop got key %d; want %d", i, h.keys[id], i)
+		}
+	}
+}
+
+func TestPosFuncInit(t *testing.T) {
+	h := newPosFuncTest(20)
+	for id := range h.keys {
+		h.keys[id] = id * 7 % 20
+		h.Values = append(h.Values, id)
+	}
+	h.Init()
+	h.verifyPos(t)
+}
+
+func TestPosFuncRandom(t *testing.T) {
+	const n = 100
+	h := newPosFuncTest(n)
+	for step := 0; step < 10000; step++ {
+		id := rand.Intn(n)
+		switch i := h.pos[id]; {
+		case i < 0:
+			h.keys[id] = rand.Intn(50)
+			h.Push(id)
+		case rand.Intn(2) == 0:
+			if got := h.Remove(i); got != id {
+				t.Fatalf("Remove(pos[%d]) = %d; want %d", id, got, id)
+			}
+		default:
+			h.keys[id] = rand.Intn(50)
+			h.Fix(i)
+		}
+		h.verifyPos(t)
+		if t.Failed() {
+			return
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Package reservoir provides weighted random sampling without replacement
+// over a stream of items of unknown length.
+package reservoir
+
+import (
+	"cmp"
+	"math"
+	"math/rand"
+
+	"github.com/hnakamur/heap"
+)
+
+// Weighted is a weighted reservoir sampler which implements the A-Res
+// algorithm by Efraimidis and Spirakis. Each item is given the random key
+// u^(1/w), where u is uniform in (0, 1] and w is the weight of the item,
+// and the k items with the largest keys are kept in a min-heap of the keys.
+// The keys are compared as log(u)/w, which is in the same order and does
+// not underflow for large weights.
+type Weighted[T any] struct {
+	k     int
+	rnd   *rand.Rand
+	items heap.Func[keyedItem[T]]
+}
+
+type keyedItem[T any] struct {
+	key  float64
+	item T
+}
+
+func compareKeyedItem[T any](a, b keyedItem[T]) int {
+	return cmp.Compare(a.key, b.key)
+}
+
+// New returns a sampler which keeps a sample of k items.
+// The random numbers are drawn from rnd, or from the default source of
+// package math/rand if rnd is nil.
+// New panics if k is negative.
+func New[T any](k int, rnd *rand.Rand) *Weighted[T] {
+	if k < 0 {
+		panic("reservoir: negative k")
+	}
+	return &Weighted[T]{
+		k:   k,
+		rnd: rnd,
+		items: heap.Func[keyedItem[T]]{
+			Values: make([]keyedItem[T], 0, k),
+			Cmp:    compareKeyedItem[T],
+		},
+	}
+}
+
+// Add offers the item with the weight to the sample.
+// An item with a zero weight is never sampled.
+// Add panics if weight is negative or NaN.
+// The complexity is O(log k).
+func (s *Weighted[T]) Add(item T, weight float64) {
+	if !(weight >= 0) {
+		panic("reservoir: negative or NaN weight")
+	}
+	if weight == 0 || s.k == 0 {
+		return
+	}
+	key := math.Log(1-s.float64()) / weight
+	if len(s.items.Values) < s.k {
+		s.items.Push(keyedItem[T]{key: key, item: item})
+		return
+	}
+	if key > s.items.Values[0].key {
+		s.items.Values[0] = keyedItem[T]{key: key, item: item}
+		s.items.Fix(0)
+	}
+}
+
+// Sample returns the sampled items in no particular order. It returns all
+// items with positive weights if fewer than k such items have been added.
+func (s *Weighted[T]) Sample() []T {
+	items := make([]T, len(s.items.Values))
+	for i, v := range s.items.Values {
+		items[i] = v.item
+	}
+	return items
+}
+
+func (s *Weighted[T]) float64() float64 {
+	if s.rnd == nil {
+		return rand.Float64()
+	}
+	return s.rnd.Float64()
+}
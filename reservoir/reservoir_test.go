@@ -0,0 +1,100 @@
+package reservoir
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestWeightedFewItems(t *testing.T) {
+	s := New[string](5, rand.New(rand.NewSource(1)))
+	s.Add("a", 1)
+	s.Add("zero", 0)
+	s.Add("b", 0.001)
+	s.Add("c", 1000)
+
+	got := s.Sample()
+	slices.Sort(got)
+	if want := []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("Sample() = %v; want %v", got, want)
+	}
+}
+
+func TestWeightedDeterministic(t *testing.T) {
+	sample := func() []int {
+		s := New[int](10, rand.New(rand.NewSource(42)))
+		for i := 0; i < 1000; i++ {
+			s.Add(i, float64(i%7+1))
+		}
+		return s.Sample()
+	}
+	a, b := sample(), sample()
+	if len(a) != 10 || !slices.Equal(a, b) {
+		t.Errorf("samples with the same seed differ: %v, %v", a, b)
+	}
+}
+
+func TestWeightedProbability(t *testing.T) {
+	// With k = 1, an item is sampled with the probability proportional
+	// to its weight.
+	weights := []float64{1, 2, 3, 4}
+	const trials = 20000
+	rnd := rand.New(rand.NewSource(1))
+	counts := make([]int, len(weights))
+	for i := 0; i < trials; i++ {
+		s := New[int](1, rnd)
+		for item, w := range weights {
+			s.Add(item, w)
+		}
+		counts[s.Sample()[0]]++
+	}
+
+	for item, w := range weights {
+		want := trials * w / 10
+		if got := float64(counts[item]); got < want*0.95 || got > want*1.05 {
+			t.Errorf("item %d sampled %g times; want about %g", item, got, want)
+		}
+	}
+}
+
+func TestWeightedZeroWeight(t *testing.T) {
+	s := New[int](3, rand.New(rand.NewSource(1)))
+	for i := 0; i < 100; i++ {
+		w := 1.0
+		if i%2 == 1 {
+			w = 0
+		}
+		s.Add(i, w)
+	}
+	for _, item := range s.Sample() {
+		if item%2 == 1 {
+			t.Errorf("item %d with zero weight was sampled", item)
+		}
+	}
+}
+
+func TestWeightedNegativeWeight(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Add with a negative weight did not panic")
+		}
+	}()
+	New[int](1, nil).Add(0, -1)
+}
+
+func TestNewNegative(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "reservoir: negative k" {
+			t.Errorf("New(-1) panicked with %v; want %q", r, "reservoir: negative k")
+		}
+	}()
+	New[int](-1, nil)
+}
+
+func TestWeightedZeroK(t *testing.T) {
+	s := New[int](0, rand.New(rand.NewSource(1)))
+	s.Add(1, 1)
+	if got := s.Sample(); len(got) != 0 {
+		t.Errorf("Sample() = %v; want empty", got)
+	}
+}
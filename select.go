@@ -0,0 +1,208 @@
+package heap
+
+import (
+	"cmp"
+	"math/bits"
+	"slices"
+)
+
+// selectHeapRatio is the minimum ratio of the length of a slice to the number
+// of selected elements for which a heap is used instead of quickselect.
+// The heap keeps only the selected elements and rarely replaces them
+// for random input, while quickselect copies and partitions the whole slice.
+const selectHeapRatio = 32
+
+// KthSmallestInt64 returns the element of s which would be at index k
+// if s were sorted in ascending order. It does not modify s.
+// KthSmallestInt64 panics if k is out of range.
+// The lower median of s is KthSmallestInt64(s, (len(s)-1)/2).
+func KthSmallestInt64(s []int64, k int) int64 { return KthSmallest(s, k) }
+
+// KthLargestInt64 returns the element of s which would be at index k
+// if s were sorted in descending order. It does not modify s.
+// KthLargestInt64 panics if k is out of range.
+func KthLargestInt64(s []int64, k int) int64 { return KthLargest(s, k) }
+
+// KthSmallestStr returns the element of s which would be at index k
+// if s were sorted in ascending order. It does not modify s.
+// KthSmallestStr panics if k is out of range.
+func KthSmallestStr(s []string, k int) string { return KthSmallest(s, k) }
+
+// KthLargestStr returns the element of s which would be at index k
+// if s were sorted in descending order. It does not modify s.
+// KthLargestStr panics if k is out of range.
+func KthLargestStr(s []string, k int) string { return KthLargest(s, k) }
+
+// KthSmallest returns the element of s which would be at index k
+// if s were sorted in ascending order. It does not modify s.
+// KthSmallest panics if k is out of range.
+//
+// The complexity is O(n log(k+1)) with a heap of k+1 elements if k is small
+// compared to n = len(s), and expected O(n) with quickselect otherwise.
+func KthSmallest[T cmp.Ordered](s []T, k int) T {
+	return kthFunc(s, k, cmp.Compare[T])
+}
+
+// KthLargest returns the element of s which would be at index k
+// if s were sorted in descending order. It does not modify s.
+// KthLargest panics if k is out of range.
+// The complexity is the same as KthSmallest.
+func KthLargest[T cmp.Ordered](s []T, k int) T {
+	return kthFunc(s, k, func(a, b T) int { return cmp.Compare(b, a) })
+}
+
+// NSmallest returns the n smallest elements of s in ascending order.
+// It is equivalent to, but less expensive than, sorting a copy of s
+// and truncating it to n elements. It does not modify s.
+// If n is greater than len(s), all elements are returned.
+func NSmallest[T cmp.Ordered](s []T, n int) []T {
+	return nFunc(s, n, cmp.Compare[T])
+}
+
+// NLargest returns the n largest elements of s in descending order.
+// It does not modify s.
+// If n is greater than len(s), all elements are returned.
+func NLargest[T cmp.Ordered](s []T, n int) []T {
+	return nFunc(s, n, func(a, b T) int { return cmp.Compare(b, a) })
+}
+
+// NSmallestFunc returns the n elements of s with the smallest keys
+// in ascending order of keys, where key is called once for each element.
+// Elements with equal keys are returned in the order they appear in s,
+// like a stable sort of s by keys. It does not modify s.
+// If n is greater than len(s), all elements are returned.
+func NSmallestFunc[T any, K cmp.Ordered](s []T, n int, key func(T) K) []T {
+	return nKeyFunc(s, n, key, cmp.Compare[K])
+}
+
+// NLargestFunc returns the n elements of s with the largest keys
+// in descending order of keys, where key is called once for each element.
+// Elements with equal keys are returned in the order they appear in s.
+// It does not modify s.
+// If n is greater than len(s), all elements are returned.
+func NLargestFunc[T any, K cmp.Ordered](s []T, n int, key func(T) K) []T {
+	return nKeyFunc(s, n, key, func(a, b K) int { return cmp.Compare(b, a) })
+}
+
+type keyedElem[T any, K cmp.Ordered] struct {
+	v   T
+	key K
+	i   int
+}
+
+func nKeyFunc[T any, K cmp.Ordered](s []T, n int, key func(T) K, c func(a, b K) int) []T {
+	elems := make([]keyedElem[T, K], len(s))
+	for i, v := range s {
+		elems[i] = keyedElem[T, K]{v: v, key: key(v), i: i}
+	}
+	best := nFunc(elems, n, func(a, b keyedElem[T, K]) int {
+		if r := c(a.key, b.key); r != 0 {
+			return r
+		}
+		return cmp.Compare(a.i, b.i)
+	})
+	ret := make([]T, len(best))
+	for i, e := range best {
+		ret[i] = e.v
+	}
+	return ret
+}
+
+func kthFunc[T any](s []T, k int, c func(a, b T) int) T {
+	if k < 0 || k >= len(s) {
+		panic("heap: k out of range")
+	}
+	_, kth := selectFunc(s, k+1, c)
+	return kth
+}
+
+func nFunc[T any](s []T, n int, c func(a, b T) int) []T {
+	n = min(n, len(s))
+	if n <= 0 {
+		return nil
+	}
+	best, _ := selectFunc(s, n, c)
+	slices.SortFunc(best, c)
+	return best
+}
+
+// selectFunc returns a new slice of the m smallest elements of s ordered by c
+// in unspecified order, and the largest of them. m must be in [1, len(s)].
+func selectFunc[T any](s []T, m int, c func(a, b T) int) (best []T, mth T) {
+	if m*selectHeapRatio > len(s) {
+		best = slices.Clone(s)
+		quickselect(best, m-1, c)
+		return best[:m], best[m-1]
+	}
+
+	// Keep the m smallest elements seen so far in a heap whose top is
+	// the largest of them.
+	h := Func[T]{
+		Values: slices.Clone(s[:m]),
+		Cmp:    func(a, b T) int { return c(b, a) },
+	}
+	h.Init()
+	for _, v := range s[m:] {
+		if c(v, h.Values[0]) < 0 {
+			h.Values[0] = v
+			h.Fix(0)
+		}
+	}
+	return h.Values, h.Values[0]
+}
+
+// quickselect reorders s so that s[k] is the element which would be at
+// index k if s were sorted by c, s[:k] are not greater than s[k], and s[k+1:]
+// are not less than s[k]. It falls back to sorting after too many bad pivots.
+func quickselect[T any](s []T, k int, c func(a, b T) int) {
+	lo, hi := 0, len(s)
+	for limit := 2 * bits.Len(uint(len(s))); hi-lo > 1; limit-- {
+		if limit == 0 {
+			slices.SortFunc(s[lo:hi], c)
+			return
+		}
+		lt, gt := partition3(s[lo:hi], c)
+		lt, gt = lo+lt, lo+gt
+		switch {
+		case k < lt:
+			hi = lt
+		case k >= gt:
+			lo = gt
+		default:
+			return
+		}
+	}
+}
+
+// partition3 partitions s around the median of its first, middle, and last
+// elements, so that s[:lt] are less than the pivot, s[lt:gt] are equal to it,
+// and s[gt:] are greater than it.
+func partition3[T any](s []T, c func(a, b T) int) (lt, gt int) {
+	a, b, d := 0, len(s)/2, len(s)-1
+	if c(s[b], s[a]) < 0 {
+		a, b = b, a
+	}
+	if c(s[d], s[b]) < 0 {
+		b = d
+		if c(s[b], s[a]) < 0 {
+			b = a
+		}
+	}
+	pivot := s[b]
+
+	lt, i, gt := 0, 0, len(s)
+	for i < gt {
+		switch r := c(s[i], pivot); {
+		case r < 0:
+			s[lt], s[i] = s[i], s[lt]
+			lt++
+			i++
+		case r > 0:
+			gt--
+			s[i], s[gt] = s[gt], s[i]
+		default:
+			i++
+		}
+	}
+	return lt, gt
+}
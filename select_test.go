@@ -0,0 +1,144 @@
+package heap
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestKthSmallestInt64(t *testing.T) {
+	for _, n := range []int{1, 2, 3, 10, 100, 1000} {
+		s := make([]int64, n)
+		for i := range s {
+			s[i] = rand.Int63n(int64(n)) - int64(n/2)
+		}
+		orig := slices.Clone(s)
+		sorted := slices.Clone(s)
+		slices.Sort(sorted)
+		for k := 0; k < n; k++ {
+			if got, want := KthSmallestInt64(s, k), sorted[k]; got != want {
+				t.Errorf("KthSmallestInt64(n=%d, k=%d) = %d; want %d", n, k, got, want)
+			}
+			if got, want := KthLargestInt64(s, k), sorted[n-1-k]; got != want {
+				t.Errorf("KthLargestInt64(n=%d, k=%d) = %d; want %d", n, k, got, want)
+			}
+		}
+		if !slices.Equal(s, orig) {
+			t.Errorf("input of length %d was modified", n)
+		}
+	}
+}
+
+func TestKthSmallestStr(t *testing.T) {
+	s := []string{"pear", "apple", "fig", "banana", "cherry", "date", "apple"}
+	sorted := slices.Clone(s)
+	slices.Sort(sorted)
+	for k := range s {
+		if got, want := KthSmallestStr(s, k), sorted[k]; got != want {
+			t.Errorf("KthSmallestStr(%d) = %q; want %q", k, got, want)
+		}
+		if got, want := KthLargestStr(s, k), sorted[len(s)-1-k]; got != want {
+			t.Errorf("KthLargestStr(%d) = %q; want %q", k, got, want)
+		}
+	}
+}
+
+func TestKthSmallestOutOfRange(t *testing.T) {
+	for _, k := range []int{-1, 3} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("KthSmallest(k=%d) did not panic", k)
+				}
+			}()
+			KthSmallest([]int{1, 2, 3}, k)
+		}()
+	}
+}
+
+func TestNSmallest(t *testing.T) {
+	for _, size := range []int{0, 1, 10, 100, 1000} {
+		s := make([]int, size)
+		for i := range s {
+			s[i] = rand.Intn(size + 1)
+		}
+		orig := slices.Clone(s)
+		sorted := slices.Clone(s)
+		slices.Sort(sorted)
+		reversed := slices.Clone(sorted)
+		slices.Reverse(reversed)
+		for _, n := range []int{-1, 0, 1, 2, size / 10, size / 2, size, size + 1} {
+			want := sorted[:max(min(n, size), 0)]
+			if got := NSmallest(s, n); !slices.Equal(got, want) {
+				t.Errorf("NSmallest(size=%d, n=%d) = %v; want %v", size, n, got, want)
+			}
+			want = reversed[:max(min(n, size), 0)]
+			if got := NLargest(s, n); !slices.Equal(got, want) {
+				t.Errorf("NLargest(size=%d, n=%d) = %v; want %v", size, n, got, want)
+			}
+		}
+		if !slices.Equal(s, orig) {
+			t.Errorf("input of length %d was modified", size)
+		}
+	}
+}
+
+func TestNSmallestFunc(t *testing.T) {
+	type record struct {
+		name string
+		age  int
+	}
+	var s []record
+	for i := 0; i < 200; i++ {
+		s = append(s, record{name: fmt.Sprint(i), age: rand.Intn(20)})
+	}
+	age := func(r record) int { return r.age }
+	for _, n := range []int{1, 5, 20, 100, 200} {
+		want := slices.Clone(s)
+		slices.SortStableFunc(want, func(a, b record) int { return cmp.Compare(a.age, b.age) })
+		if got := NSmallestFunc(s, n, age); !slices.Equal(got, want[:n]) {
+			t.Errorf("NSmallestFunc(n=%d) = %v; want %v", n, got, want[:n])
+		}
+		slices.SortStableFunc(want, func(a, b record) int { return cmp.Compare(b.age, a.age) })
+		if got := NLargestFunc(slices.Clone(s), n, age); !slices.Equal(got, want[:n]) {
+			t.Errorf("NLargestFunc(n=%d) = %v; want %v", n, got, want[:n])
+		}
+	}
+}
+
+func TestNSmallestFuncFold(t *testing.T) {
+	s := []string{"b", "C", "a", "B", "c", "A"}
+	got := NSmallestFunc(s, 4, strings.ToLower)
+	if want := []string{"a", "A", "b", "B"}; !slices.Equal(got, want) {
+		t.Errorf("NSmallestFunc = %q; want %q", got, want)
+	}
+}
+
+func TestQuickselectSorted(t *testing.T) {
+	// Sorted and constant inputs are common inputs with bad pivots
+	// for naive quickselect.
+	for _, s := range [][]int{
+		{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		{15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1, 0},
+		{3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3},
+	} {
+		sorted := slices.Clone(s)
+		slices.Sort(sorted)
+		for k := range s {
+			c := slices.Clone(s)
+			quickselect(c, k, cmp.Compare[int])
+			if c[k] != sorted[k] {
+				t.Errorf("quickselect(%v, %d) placed %d; want %d", s, k, c[k], sorted[k])
+			}
+			for i := range c {
+				if (i < k && c[i] > c[k]) || (i > k && c[i] < c[k]) {
+					t.Errorf("quickselect(%v, %d) = %v; not partitioned", s, k, c)
+					break
+				}
+			}
+		}
+	}
+}
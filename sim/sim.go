@@ -0,0 +1,106 @@
+// Package sim provides a discrete-event simulation engine whose event queue
+// is a heap ordered by virtual time.
+package sim
+
+import (
+	"cmp"
+
+	"github.com/hnakamur/heap"
+)
+
+// Time is a virtual time of a simulation. Its unit is up to the user.
+type Time int64
+
+// Event is a handle of a scheduled event.
+type Event struct {
+	at  Time
+	seq uint64
+	fn  func()
+}
+
+// At returns the time when the event is scheduled to run.
+func (e *Event) At() Time { return e.at }
+
+// Simulator runs events in the order of their times. Events scheduled at
+// the same time run in the order they are scheduled.
+type Simulator struct {
+	now   Time
+	seq   uint64
+	queue *heap.IndexedFunc[*Event]
+}
+
+// New returns a new Simulator whose current time is zero.
+func New() *Simulator {
+	return &Simulator{
+		queue: heap.NewIndexedFunc(compareEvent),
+	}
+}
+
+func compareEvent(a, b *Event) int {
+	if c := cmp.Compare(a.at, b.at); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.seq, b.seq)
+}
+
+// Now returns the current virtual time.
+func (s *Simulator) Now() Time { return s.now }
+
+// Len returns the number of events which are scheduled and not canceled.
+func (s *Simulator) Len() int { return s.queue.Len() }
+
+// Schedule schedules fn to run at the time at and returns the event.
+// fn may schedule or cancel other events. Schedule panics if at is before
+// the current time.
+func (s *Simulator) Schedule(at Time, fn func()) *Event {
+	if at < s.now {
+		panic("sim: Schedule in the past")
+	}
+	e := &Event{at: at, seq: s.seq, fn: fn}
+	s.seq++
+	s.queue.Push(e)
+	return e
+}
+
+// After schedules fn to run after the duration d from the current time.
+func (s *Simulator) After(d Time, fn func()) *Event {
+	return s.Schedule(s.now+d, fn)
+}
+
+// Cancel cancels the event e and removes it from the queue. It returns false
+// if e has already run or has already been canceled.
+// The complexity is O(log n) where n = s.Len().
+func (s *Simulator) Cancel(e *Event) bool {
+	if !s.queue.Delete(e) {
+		return false
+	}
+	e.fn = nil // release the closure even if the caller keeps e
+	return true
+}
+
+// Step advances the current time to the time of the next event and runs it.
+// It returns false if there are no events.
+func (s *Simulator) Step() bool {
+	if s.queue.Len() == 0 {
+		return false
+	}
+	e := s.queue.Pop()
+	s.now = e.at
+	fn := e.fn
+	e.fn = nil
+	fn()
+	return true
+}
+
+// RunUntil runs the events scheduled at or before the time t in order,
+// including events scheduled by them, and then advances the current
+// time to t. RunUntil panics if t is before the current time.
+func (s *Simulator) RunUntil(t Time) {
+	if t < s.now {
+		panic("sim: RunUntil in the past")
+	}
+	for s.queue.Len() > 0 && s.queue.Min().at <= t {
+		s.Step()
+	}
+	s.now = t
+}
@@ -0,0 +1,185 @@
+package sim
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestSimulatorOrder(t *testing.T) {
+	s := New()
+	var got []string
+	record := func(name string) func() {
+		return func() { got = append(got, fmt.Sprintf("%s@%d", name, s.Now())) }
+	}
+
+	s.Schedule(30, record("c"))
+	s.Schedule(10, record("a1"))
+	s.Schedule(20, record("b"))
+	s.Schedule(10, record("a2")) // same time as a1, runs after a1
+	s.Schedule(10, func() {
+		got = append(got, fmt.Sprintf("a3@%d", s.Now()))
+		s.After(0, record("a4")) // scheduled now, runs after a3
+		s.After(15, record("d"))
+	})
+	if s.Len() != 5 {
+		t.Errorf("Len() = %d; want 5", s.Len())
+	}
+
+	for s.Step() {
+	}
+	want := []string{"a1@10", "a2@10", "a3@10", "a4@10", "b@20", "d@25", "c@30"}
+	if !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if s.Now() != 30 || s.Len() != 0 {
+		t.Errorf("Now() = %d, Len() = %d; want 30, 0", s.Now(), s.Len())
+	}
+}
+
+func TestSimulatorCancel(t *testing.T) {
+	s := New()
+	var got []int
+	events := make([]*Event, 5)
+	for i := range events {
+		i := i
+		events[i] = s.Schedule(Time(i), func() { got = append(got, i) })
+	}
+	// An event can cancel a later event.
+	s.Schedule(0, func() {
+		if !s.Cancel(events[3]) {
+			t.Error("Cancel(events[3]) = false; want true")
+		}
+	})
+
+	if !s.Cancel(events[1]) {
+		t.Error("Cancel(events[1]) = false; want true")
+	}
+	if s.Cancel(events[1]) {
+		t.Error("second Cancel(events[1]) = true; want false")
+	}
+	if s.Len() != 5 {
+		t.Errorf("Len() = %d; want 5", s.Len())
+	}
+
+	s.RunUntil(10)
+	if want := []int{0, 2, 4}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if s.Cancel(events[0]) {
+		t.Error("Cancel of a fired event = true; want false")
+	}
+	if s.Len() != 0 {
+		t.Errorf("Len() = %d; want 0", s.Len())
+	}
+}
+
+func TestSimulatorCancelTimeouts(t *testing.T) {
+	// Timeouts far in the future which are almost always canceled
+	// must not pile up in the queue.
+	s := New()
+	fired := 0
+	var step func()
+	step = func() {
+		timeout := s.After(1_000_000, func() { t.Error("timeout fired") })
+		if !s.Cancel(timeout) {
+			t.Error("Cancel(timeout) = false; want true")
+		}
+		if timeout.fn != nil {
+			t.Error("canceled event keeps its function")
+		}
+		if fired++; fired < 10000 {
+			s.After(1, step)
+		}
+	}
+	s.After(1, step)
+	s.RunUntil(100_000)
+
+	if fired != 10000 {
+		t.Errorf("fired %d steps; want 10000", fired)
+	}
+	if n := s.queue.Len(); n != 0 {
+		t.Errorf("queue has %d events after cancels; want 0", n)
+	}
+}
+
+func TestSimulatorRunUntil(t *testing.T) {
+	s := New()
+	var got []Time
+	var tick func()
+	tick = func() {
+		got = append(got, s.Now())
+		s.After(10, tick)
+	}
+	s.Schedule(5, tick)
+
+	s.RunUntil(30)
+	if want := []Time{5, 15, 25}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if s.Now() != 30 {
+		t.Errorf("Now() = %d; want 30", s.Now())
+	}
+
+	// An event at exactly the time is run.
+	s.RunUntil(35)
+	if want := []Time{5, 15, 25, 35}; !slices.Equal(got, want) {
+		t.Errorf("got %v; want %v", got, want)
+	}
+	if s.Len() != 1 {
+		t.Errorf("Len() = %d; want 1", s.Len())
+	}
+}
+
+func TestSimulatorSchedulePast(t *testing.T) {
+	s := New()
+	s.RunUntil(10)
+	defer func() {
+		if recover() == nil {
+			t.Error("Schedule in the past did not panic")
+		}
+	}()
+	s.Schedule(5, func() {})
+}
+
+// TestSimulatorQueue simulates a single server queue with deterministic
+// arrivals and service times, and checks the waiting times.
+func TestSimulatorQueue(t *testing.T) {
+	s := New()
+	arrivals := []Time{0, 1, 2, 10, 11}
+	const service = 3
+
+	var (
+		busy    bool
+		waiting []Time // arrival times of waiting customers
+		waits   []Time
+	)
+	var start func(arrival Time)
+	start = func(arrival Time) {
+		busy = true
+		waits = append(waits, s.Now()-arrival)
+		s.After(service, func() {
+			busy = false
+			if len(waiting) > 0 {
+				next := waiting[0]
+				waiting = waiting[1:]
+				start(next)
+			}
+		})
+	}
+	for _, at := range arrivals {
+		at := at
+		s.Schedule(at, func() {
+			if busy {
+				waiting = append(waiting, at)
+			} else {
+				start(at)
+			}
+		})
+	}
+
+	s.RunUntil(100)
+	if want := []Time{0, 2, 4, 0, 2}; !slices.Equal(waits, want) {
+		t.Errorf("waits = %v; want %v", waits, want)
+	}
+}
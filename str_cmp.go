@@ -0,0 +1,87 @@
+package heap
+
+import "strings"
+
+// CompareFoldASCII compares a and b like strings.Compare, but ASCII letters
+// are compared case-insensitively. Non-ASCII bytes are compared as is.
+// Strings which differ only in the case of ASCII letters are equal.
+func CompareFoldASCII(a, b string) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		ca, cb := lowerASCII(a[i]), lowerASCII(b[i])
+		if ca != cb {
+			if ca < cb {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
+
+func lowerASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// CompareNatural compares a and b like strings.Compare, but runs of ASCII
+// digits are compared by their numeric values, so that "file2" is ordered
+// before "file10". Numbers of any length are supported since they are not
+// converted to integers. When a and b are equal except for leading zeros
+// in numbers, they are ordered by strings.Compare.
+func CompareNatural(a, b string) int {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if isDigit(a[i]) && isDigit(b[j]) {
+			i0, j0 := i, j
+			for i < len(a) && isDigit(a[i]) {
+				i++
+			}
+			for j < len(b) && isDigit(b[j]) {
+				j++
+			}
+			na := strings.TrimLeft(a[i0:i], "0")
+			nb := strings.TrimLeft(b[j0:j], "0")
+			switch {
+			case len(na) < len(nb):
+				return -1
+			case len(na) > len(nb):
+				return 1
+			}
+			if c := strings.Compare(na, nb); c != 0 {
+				return c
+			}
+			continue
+		}
+		if a[i] != b[j] {
+			if a[i] < b[j] {
+				return -1
+			}
+			return 1
+		}
+		i++
+		j++
+	}
+	switch {
+	case i < len(a):
+		return 1
+	case j < len(b):
+		return -1
+	}
+	return strings.Compare(a, b)
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
@@ -0,0 +1,63 @@
+package heap
+
+import "testing"
+
+func sign(c int) int {
+	switch {
+	case c < 0:
+		return -1
+	case c > 0:
+		return 1
+	}
+	return 0
+}
+
+func TestCompareFoldASCII(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "ABC", 0},
+		{"abc", "abd", -1},
+		{"ABD", "abc", 1},
+		{"ab", "ABC", -1},
+		{"Zebra", "apple", 1},
+		{"_", "a", -1},
+		{"é", "É", 1},
+	}
+	for _, tc := range testCases {
+		if got := sign(CompareFoldASCII(tc.a, tc.b)); got != tc.want {
+			t.Errorf("CompareFoldASCII(%q, %q) = %d; want %d", tc.a, tc.b, got, tc.want)
+		}
+		if got := sign(CompareFoldASCII(tc.b, tc.a)); got != -tc.want {
+			t.Errorf("CompareFoldASCII(%q, %q) = %d; want %d", tc.b, tc.a, got, -tc.want)
+		}
+	}
+}
+
+func TestCompareNatural(t *testing.T) {
+	testCases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"file2", "file10", -1},
+		{"file10", "file10", 0},
+		{"file02", "file2", -1},
+		{"file02a", "file2b", -1},
+		{"file", "file1", -1},
+		{"1", "a", -1},
+		{"a1b2", "a1b10", -1},
+		{"v1.10.0", "v1.9.3", 1},
+		{"99999999999999999999999", "100000000000000000000000", -1},
+	}
+	for _, tc := range testCases {
+		if got := sign(CompareNatural(tc.a, tc.b)); got != tc.want {
+			t.Errorf("CompareNatural(%q, %q) = %d; want %d", tc.a, tc.b, got, tc.want)
+		}
+		if got := sign(CompareNatural(tc.b, tc.a)); got != -tc.want {
+			t.Errorf("CompareNatural(%q, %q) = %d; want %d", tc.b, tc.a, got, -tc.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2009 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package heap
+
+// StrFunc is a heap for getting the minimum string value ordered by Cmp.
+// It has the same sift logic as MinStr, but the ordering is pluggable so that
+// user-facing names can be ordered by CompareFoldASCII, CompareNatural,
+// or a collator such as the CompareString method of
+// golang.org/x/text/collate.Collator.
+type StrFunc struct {
+	// Values holds the elements of the heap.
+	Values []string
+
+	// Cmp returns a negative number when a is less than b, zero when a equals b,
+	// and a positive number when a is greater than b, like strings.Compare.
+	// The maximum version of heap can be made by negating the result.
+	Cmp func(a, b string) int
+}
+
+// Init establishes the heap invariants required by the other routines in this package.
+// Init is idempotent with respect to the heap invariants
+// and may be called whenever the heap invariants may have been invalidated.
+// The complexity is O(n) where n = len(h.Values).
+func (h *StrFunc) Init() {
+	// heapify
+	n := h.length()
+	for i := n/2 - 1; i >= 0; i-- {
+		h.down(i, n)
+	}
+}
+
+// Push pushes the element x onto the heap.
+// The complexity is O(log n) where n = len(h.Values).
+func (h *StrFunc) Push(x string) {
+	h.push(x)
+	h.up(h.length() - 1)
+}
+
+// Pop removes and returns the minimum element from the heap.
+// The complexity is O(log n) where n = len(h.Values).
+// Pop is equivalent to Remove(h, 0).
+func (h *StrFunc) Pop() string {
+	n := h.length() - 1
+	h.swap(0, n)
+	h.down(0, n)
+	return h.pop()
+}
+
+// Remove removes and returns the element at index i from the heap.
+// The complexity is O(log n) where n = len(h.Values).
+func (h *StrFunc) Remove(i int) string {
+	n := h.length() - 1
+	if n != i {
+		h.swap(i, n)
+		if !h.down(i, n) {
+			h.up(i)
+		}
+	}
+	return h.pop()
+}
+
+// Fix re-establishes the heap ordering after the element at index i has changed its value.
+// Changing the value of the element at index i and then calling Fix is equivalent to,
+// but less expensive than, calling Remove(h, i) followed by a Push of the new value.
+// The complexity is O(log n) where n = len(h.Values).
+func (h *StrFunc) Fix(i int) {
+	if !h.down(i, h.length()) {
+		h.up(i)
+	}
+}
+
+func (h *StrFunc) up(j int) {
+	for {
+		i := (j - 1) / 2 // parent
+		if i == j || !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		j = i
+	}
+}
+
+func (h *StrFunc) down(i0, n int) bool {
+	i := i0
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 { // j1 < 0 after int overflow
+			break
+		}
+		j := j1 // left child
+		if j2 := j1 + 1; j2 < n && h.less(j2, j1) {
+			j = j2 // = 2*i + 2  // right child
+		}
+		if !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		i = j
+	}
+	return i > i0
+}
+
+func (h *StrFunc) length() int        { return len(h.Values) }
+func (h *StrFunc) less(i, j int) bool { return h.Cmp(h.Values[i], h.Values[j]) < 0 }
+func (h *StrFunc) swap(i, j int)      { h.Values[i], h.Values[j] = h.Values[j], h.Values[i] }
+
+func (h *StrFunc) push(x string) {
+	h.Values = append(h.Values, x)
+}
+
+func (h *StrFunc) pop() (x string) {
+	h.Values, x = h.Values[:h.length()-1], h.Values[h.length()-1]
+	return
+}
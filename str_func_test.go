@@ -0,0 +1,122 @@
+package heap
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func (h *StrFunc) verify(t *testing.T, i int) {
+	t.Helper()
+	n := h.length()
+	j1 := 2*i + 1
+	j2 := 2*i + 2
+	if j1 < n {
+		if h.less(j1, i) {
+			t.Errorf("heap invariant invalidated [%d] = %s > [%d] = %s", i, h.Values[i], j1, h.Values[j1])
+			return
+		}
+		h.verify(t, j1)
+	}
+	if j2 < n {
+		if h.less(j2, i) {
+			t.Errorf("heap invariant invalidated [%d] = %s > [%d] = %s", i, h.Values[i], j1, h.Values[j2])
+			return
+		}
+		h.verify(t, j2)
+	}
+}
+
+// reverseCollator is a stand-in for golang.org/x/text/collate.Collator
+// which orders strings in reverse.
+type reverseCollator struct{}
+
+func (reverseCollator) CompareString(a, b string) int { return -strings.Compare(a, b) }
+
+func TestStrFunc(t *testing.T) {
+	testCases := []struct {
+		name string
+		cmp  func(a, b string) int
+		want []string
+	}{
+		{
+			name: "FoldASCII",
+			cmp:  CompareFoldASCII,
+			want: []string{"apple", "Banana", "cherry", "Date", "eggplant"},
+		},
+		{
+			name: "Natural",
+			cmp:  CompareNatural,
+			want: []string{"file1", "file2", "file10", "file10a", "file20", "file100"},
+		},
+		{
+			name: "Collator",
+			cmp:  reverseCollator{}.CompareString,
+			want: []string{"c", "b", "a", "B", "A"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &StrFunc{Cmp: tc.cmp}
+			for _, i := range rand.Perm(len(tc.want)) {
+				h.Push(tc.want[i])
+				h.verify(t, 0)
+			}
+			for i := 0; h.length() > 0; i++ {
+				x := h.Pop()
+				h.verify(t, 0)
+				if x != tc.want[i] {
+					t.Errorf("%d.th pop got %s; want %s", i, x, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStrFuncRemove(t *testing.T) {
+	N := 10
+
+	h := &StrFunc{Cmp: CompareNatural}
+	for i := 0; i < N; i++ {
+		h.Push("item" + strings.Repeat("1", i+1))
+	}
+	h.verify(t, 0)
+
+	m := make(map[string]bool)
+	for h.length() > 0 {
+		m[h.Remove((h.length()-1)/2)] = true
+		h.verify(t, 0)
+	}
+
+	if len(m) != N {
+		t.Errorf("len(m) = %d; want %d", len(m), N)
+	}
+}
+
+func TestStrFuncFix(t *testing.T) {
+	h := &StrFunc{Cmp: strings.Compare}
+	h.verify(t, 0)
+
+	for i := 200; i > 0; i -= 10 {
+		h.Push(toHex(uint64(i)))
+	}
+	h.verify(t, 0)
+
+	if h.Values[0] != toHex(10) {
+		t.Fatalf("Expected head to be 10, was %s", h.Values[0])
+	}
+	h.Values[0] = toHex(210)
+	h.Fix(0)
+	h.verify(t, 0)
+
+	for i := 100; i > 0; i-- {
+		elem := rand.Intn(h.length())
+		if i&1 == 0 {
+			h.Values[elem] = toHex(fromHex(t, h.Values[elem]) * 2)
+		} else {
+			h.Values[elem] = toHex(fromHex(t, h.Values[elem]) / 2)
+		}
+		h.Fix(elem)
+		h.verify(t, 0)
+	}
+}